@@ -24,34 +24,152 @@ const AverageNumberOfTasksPerWorker = 2
 const ImageResolutionX = 640
 const ImageResolutionY = 360
 const ImageFileName = "output.png"
+const ImageBitDepth = 8
+const ExrFileName = "output.exr"
+const WriteExrImage = false
+
+// Arbitrary output variables, each one is written to a file named after the AOV
+const AovFileExtension = ".png"
+const AovMaxPosition = 2.0
+
+// Output transform
+const Exposure = 0.0
+
+// Debug visualizations, any mode other than DebugModeNone replaces the shading
+const RenderDebugMode = DebugModeNone
+const DebugIsolineSpacing = 0.1
 
 // Camera constants
-const RayStepSize = 0.01
 const CameraNearPlane = 0.001
 const CameraFarPlane = 25.0
 
-// Light parameters
+// Ray marching
+const UseSphereTracing = true
+const RayStepSize = 0.01
+const SphereTraceRelaxation = 1.2
+const SphereTraceMinHitDistance = 0.0005
+const SphereTraceMaxIterations = 256
+
+// Fractal parameters
+const MandelbulbIterations = 10
+const MandelbulbPower = 8
+const MandelbulbBailout = 5.0
+const UseOrbitTrapColoring = true
+
+// Shading
+const UsePhysicallyBasedShading = false
+
+// Reflections and refractions
+const ReflectionMaxDepth = 4
+const ReflectionBias = 0.002
+
+// Path tracing
+const UsePathTracing = false
+const PathTracingSamplesPerPixel = 16
+const PathTracingMaxDepth = 4
+const PathTracingRussianRouletteDepth = 2
+const PathTracingBias = 0.002
+
+// Fog, a density of zero disables the effect
+const DistanceFogDensity = 0.0
+const HeightFogDensity = 0.0
+const HeightFogFalloff = 2.0
+const HeightFogBaseHeight = -1.0
+const MediumDensity = 0.0
+const MediumAnisotropy = 0.6
+const MediumStepCount = 32
+
+// Environment, an environment map is used when a file name is specified, otherwise the sky model or the
+// background gradient is used
+const EnvironmentMapFileName = ""
+const EnvironmentMapIntensity = 1.0
+const UseSkyModel = false
+const SkyTurbidity = 3.0
+const SkyIntensity = 0.5
+const UseEnvironmentLighting = false
+const EnvironmentLightIntensity = 1.0
+const EnvironmentLightSamples = 16
+
+// Light parameters, the shaders divide diffuse reflection by pi so an intensity of pi fully lights a white surface
+// that faces the light
 const AmbientStrength = 0.25
-const SpecularStrength = 0.5
-const SpecularShininess = 32
+const KeyLightIntensity = math.Pi
+const KeyLightShadowSoftness = 0.0625
+const FillLightIntensity = 0.25 * math.Pi
+const FillLightShadowSoftness = 0.0
+
+// Ambient occlusion
+const UseAmbientOcclusion = true
+const AmbientOcclusionStepCount = 5
+const AmbientOcclusionStepDistance = 0.01
+const AmbientOcclusionStrength = 20.0
+const AmbientOcclusionHemisphereSamples = 4
+
+// Shadows
+const ShadowBias = 0.002
+const ShadowMinHitDistance = 0.0005
+const ShadowMaxIterations = 128
 
 // Camera transformation
 var cameraPosition = Vec3{X: 0.0, Y: 0, Z: -1.525}
 var cameraLookAt = Vec3{X: 0.0, Y: 0.0, Z: 0.0}
 
+// Slicing plane for the isoline debug visualization, faces the camera through the center of the fractal
+var debugSlicePoint = Vec3{X: 0.0, Y: 0.0, Z: 0.0}
+var debugSliceNormal = Vec3{X: 0.0, Y: 0.0, Z: 1.0}
+
 // Lights
-var ambientLightPosition = Vec3{X: -10.0, Y: 10.0, Z: -10.0}
+var keyLightPosition = Vec3{X: -10.0, Y: 10.0, Z: -10.0}
+var fillLightDirection = Vec3{X: 1.0, Y: 0.5, Z: 1.0}
+var sunDirection = Vec3{X: 1.0, Y: 0.75, Z: 1.0}
 
 // Colors
 var ambientColor = Color{Red: 255, Green: 255, Blue: 255, Alpha: 255}
+var keyLightColor = Color{Red: 255, Green: 255, Blue: 255, Alpha: 255}
+var fillLightColor = Color{Red: 52, Green: 152, Blue: 219, Alpha: 255}
 var surfaceColor = Color{Red: 26, Green: 188, Blue: 156, Alpha: 255}
+var fogColor = Color{Red: 189, Green: 195, Blue: 199, Alpha: 255}
+
+// Materials
+const (
+	MandelbulbMaterialID MaterialID = iota
+)
+
+var materials = []Material{
+	MandelbulbMaterialID: NewMaterial(surfaceColor.AsNormalizedVec3(), 0.5, 0.0),
+}
+
+// Fractal coloring
+var orbitTrapGradient = NewGradient(
+	NewColorStop(0.0, Color{Red: 243, Green: 156, Blue: 18, Alpha: 255}),
+	NewColorStop(0.5, surfaceColor),
+	NewColorStop(1.0, Color{Red: 44, Green: 62, Blue: 80, Alpha: 255}),
+)
+var orbitTrapColoring = NewOrbitTrapColoring(ChannelPointDistance, orbitTrapGradient, 1.0, 0.0, false)
+
+// Background
+var backgroundGradient = NewGradient(
+	NewColorStop(0.0, Color{Red: 20, Green: 20, Blue: 24, Alpha: 255}),
+	NewColorStop(1.0, Color{Red: 44, Green: 62, Blue: 80, Alpha: 255}),
+)
 
 // =============================================================================================================================
 // =============================================================================================================================
 // =============================================================================================================================
 
-var scene = NewScene(sceneSDF)
+var scene = createScene()
+var shader = createShader()
+var outputTransform = createOutputTransform()
+var aovs = []Aov{}
+var aovRanges = NewAovRanges(CameraFarPlane, AovMaxPosition, float64(maxStepCount()))
 var camera = NewCamera(cameraPosition, cameraLookAt, CameraNearPlane, CameraFarPlane)
+var sphereTraceSettings = NewSphereTraceSettings(SphereTraceRelaxation, SphereTraceMinHitDistance, SphereTraceMaxIterations)
+var ambientOcclusionSettings = NewAmbientOcclusionSettings(AmbientOcclusionStepCount, AmbientOcclusionStepDistance, AmbientOcclusionStrength, AmbientOcclusionHemisphereSamples)
+var shadowSettings = NewShadowSettings(ShadowBias, ShadowMinHitDistance, CameraFarPlane, ShadowMaxIterations)
+var recursiveTracer = NewRecursiveTracer(NewRecursiveTracerSettings(ReflectionMaxDepth, ReflectionBias), sphereTraceSettings, shadeSecondarySurface)
+var pathTracerSettings = NewPathTracerSettings(PathTracingSamplesPerPixel, PathTracingMaxDepth, PathTracingRussianRouletteDepth, PathTracingBias)
+var pathTracer = NewPathTracer(pathTracerSettings, sphereTraceSettings, shadowSettings)
+var debugSettings = NewDebugSettings(RenderDebugMode, CameraFarPlane, maxStepCount(), SphereTraceMinHitDistance, debugSlicePoint, debugSliceNormal, DebugIsolineSpacing)
 
 // =============================================================================================================================
 // =============================================================================================================================
@@ -65,7 +183,9 @@ type RenderTask struct {
 // Represents the result of a render task
 type RenderResult struct {
 	StartRow, RowCount int
-	Pixels             []Color
+	Pixels             Framebuffer
+	Aovs               AovBuffers
+	StepCount          int
 }
 
 // Log the time since the start time
@@ -73,27 +193,125 @@ func trackTime(start time.Time, name string) {
 	log.Printf("%s took %s", name, time.Since(start))
 }
 
-// Entire scene represented as a signed distance function
-func sceneSDF(point Vec3) float64 {
-	return MandelbulbSDF(point, 10, 8, 5.0)
+// Pick the shader that calculates the color of each surface point
+func createShader() Shader {
+	if UsePhysicallyBasedShading {
+		return NewCookTorranceShader()
+	}
+
+	return NewBlinnPhongShader()
 }
 
-// Simple Blinn-Phong lighting model
-//
-// Reference: https://learnopengl.com/Advanced-Lighting/Advanced-Lighting
-func calculatePixelColor(surfaceInfo SurfaceHitInfo, camera Camera) Color {
-	ambientLightDirection := Normalize(Sub(ambientLightPosition, surfaceInfo.Point))
-	viewDirection := Normalize(Sub(camera.Position, surfaceInfo.Point))
-	halfwayDirection := Normalize(Add(ambientLightDirection, viewDirection))
+// Pick the output transform, debug visualizations are stored as is to keep their false colors intact
+func createOutputTransform() OutputTransform {
+	if RenderDebugMode != DebugModeNone {
+		return NewOutputTransform(0.0, NewClampToneMapper())
+	}
 
-	ambient := MultiplyScalar(ambientColor.AsNormalizedVec3(), AmbientStrength)
-	diffuse := MultiplyScalar(ambientColor.AsNormalizedVec3(), math.Max(Dot(surfaceInfo.Normal, ambientLightDirection), 0.0))
-	specular := MultiplyScalar(ambientColor.AsNormalizedVec3(), math.Pow(math.Max(Dot(surfaceInfo.Normal, halfwayDirection), 0.0), SpecularShininess)*SpecularStrength)
+	return NewOutputTransform(Exposure, NewAcesToneMapper())
+}
 
-	lightColor := AddAll(ambient, diffuse, specular)
-	outputColor := Multiply(lightColor, surfaceColor.AsNormalizedVec3())
+// Pick the environment that surrounds the scene
+func createEnvironment() Environment {
+	if EnvironmentMapFileName != "" {
+		environmentMap, error := LoadEnvironmentMap(EnvironmentMapFileName, EnvironmentMapIntensity, 0.0)
+		if error == nil {
+			return environmentMap
+		}
+
+		log.Println("Unable to load the environment map, falling back to the default environment:", error)
+	}
+
+	if UseSkyModel {
+		return NewPreethamSky(sunDirection, SkyTurbidity, SkyIntensity)
+	}
 
-	return ColorFromNormalizedVec3(outputColor)
+	return NewGradientEnvironment(backgroundGradient)
+}
+
+// Build the scene that will be rendered
+func createScene() Scene {
+	mandelbulb := NewMandelbulbNode(MandelbulbIterations, MandelbulbPower, MandelbulbBailout)
+	scene := NewSceneFromGraph(NewMaterialNode(mandelbulb, MandelbulbMaterialID), materials...)
+	keyLight := NewPointLight(keyLightPosition, keyLightColor.AsNormalizedVec3(), KeyLightIntensity, NoAttenuation())
+	keyLight.ShadowSoftness = KeyLightShadowSoftness
+	scene.AddLight(keyLight)
+
+	fillLight := NewDirectionalLight(fillLightDirection, fillLightColor.AsNormalizedVec3(), FillLightIntensity)
+	fillLight.ShadowSoftness = FillLightShadowSoftness
+	scene.AddLight(fillLight)
+
+	environment := createEnvironment()
+	scene.SetEnvironment(environment)
+
+	if UseEnvironmentLighting {
+		scene.AddLight(NewEnvironmentLight(environment, EnvironmentLightIntensity, EnvironmentLightSamples))
+	}
+
+	if UseOrbitTrapColoring {
+		scene.SetOrbitTrapFunction(func(point Vec3) OrbitTrap {
+			trap := NewOrbitTrap(Vec3{}, Vec3{X: 0.0, Y: 1.0, Z: 0.0}, Vec3{X: 0.0, Y: 1.0, Z: 0.0})
+			MandelbulbOrbitTrapSDF(point, MandelbulbIterations, MandelbulbPower, MandelbulbBailout, &trap)
+			return trap
+		})
+		scene.SetOrbitTrapColoring(orbitTrapColoring)
+	}
+
+	if DistanceFogDensity > 0.0 {
+		scene.AddFog(NewDistanceFog(fogColor.AsNormalizedVec3(), DistanceFogDensity))
+	}
+
+	if HeightFogDensity > 0.0 {
+		scene.AddFog(NewHeightFog(fogColor.AsNormalizedVec3(), HeightFogDensity, HeightFogFalloff, HeightFogBaseHeight))
+	}
+
+	if MediumDensity > 0.0 {
+		scene.AddFog(NewHomogeneousMedium(MediumDensity, Vec3{X: 1.0, Y: 1.0, Z: 1.0}, MediumAnisotropy, MediumStepCount, shadowSettings))
+	}
+
+	return scene
+}
+
+// Shade a surface point with the configured shader
+func shadeSurface(surfaceInfo SurfaceHitInfo, viewDirection Vec3, occlusion float64) Vec3 {
+	context := ShadingContext{
+		Point:         surfaceInfo.Point,
+		Normal:        surfaceInfo.Normal,
+		ViewDirection: viewDirection,
+		Material:      scene.GetSurfaceMaterial(surfaceInfo),
+		AmbientLight:  MultiplyScalar(ambientColor.AsNormalizedVec3(), AmbientStrength*occlusion),
+		Lights:        scene.GatherVisibleLight(surfaceInfo.Point, surfaceInfo.Normal, shadowSettings),
+	}
+
+	return shader.Shade(context)
+}
+
+// Shade a surface point that is seen in a reflection or through a refraction
+func shadeSecondarySurface(surfaceInfo SurfaceHitInfo, viewDirection Vec3) Vec3 {
+	occlusion := 1.0
+	if UseAmbientOcclusion {
+		occlusion = scene.AmbientOcclusion(surfaceInfo.Point, surfaceInfo.Normal, ambientOcclusionSettings)
+	}
+
+	return shadeSurface(surfaceInfo, viewDirection, occlusion)
+}
+
+// Calculate the ambient occlusion stored in the AOV of a pixel, which is only done when the AOV is requested
+func calculateAovOcclusion(aovBuffers *AovBuffers, didHit bool, hitInfo SurfaceHitInfo) float64 {
+	if _, exists := aovBuffers.GetBuffer(AovAmbientOcclusion); !exists || !didHit {
+		return 1.0
+	}
+
+	return scene.AmbientOcclusion(hitInfo.Point, hitInfo.Normal, ambientOcclusionSettings)
+}
+
+// Calculate the color of the surface a primary ray hit, including its reflections, refractions, and the fog
+// in front of it
+func calculatePixelColor(surfaceInfo SurfaceHitInfo, ray Ray, occlusion float64) Vec3 {
+	localColor := shadeSurface(surfaceInfo, Negate(Normalize(ray.Direction)), occlusion)
+	color := recursiveTracer.ShadeSurface(&scene, surfaceInfo, ray, localColor, CameraFarPlane)
+
+	return scene.ApplyFog(ray, surfaceInfo.RayLength, color)
 }
 
 // Worker GoRoutine that fetches a render task from the queue and executes it
@@ -114,24 +332,85 @@ func renderWorker(waitGroup *sync.WaitGroup, pendingWorkQueue <-chan RenderTask,
 	}
 }
 
+// Largest number of steps a ray can take with the configured marching strategy, fixed steps keep going until the
+// ray passes the far plane
+func maxStepCount() int {
+	if UseSphereTracing {
+		return SphereTraceMaxIterations
+	}
+
+	return int(math.Ceil(CameraFarPlane / RayStepSize))
+}
+
+// March a ray into the scene using the configured marching strategy
+func marchAlongRay(ray Ray) (bool, SurfaceHitInfo) {
+	if UseSphereTracing {
+		return camera.SphereTraceAlongRay(ray, scene, sphereTraceSettings)
+	}
+
+	return camera.MarchAlongRay(ray, scene, RayStepSize)
+}
+
 // Render the scene
 func render(task RenderTask) RenderResult {
-	renderResult := RenderResult{task.StartRow, task.RowCount, make([]Color, task.RowCount*ImageResolutionX)}
+	renderResult := RenderResult{
+		StartRow: task.StartRow,
+		RowCount: task.RowCount,
+		Pixels:   NewFramebuffer(ImageResolutionX, task.RowCount),
+		Aovs:     NewAovBuffers(ImageResolutionX, task.RowCount, aovs...),
+	}
 
-	pixelIndex := 0
 	for y := task.StartRow; y < task.StartRow+task.RowCount; y++ {
 		for x := 0; x < ImageResolutionX; x++ {
+			row := y - task.StartRow
+
+			if RenderDebugMode != DebugModeNone {
+				ray := camera.GenerateRayForPixelCenter(x, y, ImageResolutionX, ImageResolutionY)
+				didHit, hitInfo := marchAlongRay(ray)
+				renderResult.StepCount += hitInfo.StepCount
+
+				renderResult.Pixels.SetPixel(x, row, scene.DebugColor(ray, didHit, hitInfo, debugSettings), 1.0)
+				renderResult.Aovs.Record(x, row, didHit, hitInfo, calculateAovOcclusion(&renderResult.Aovs, didHit, hitInfo))
+				continue
+			}
+
+			if UsePathTracing {
+				renderResult.Pixels.SetPixel(x, row, pathTracer.RenderPixel(&scene, &camera, x, y, ImageResolutionX, ImageResolutionY), 1.0)
+
+				// The AOVs describe the surface at the center of the pixel
+				if !renderResult.Aovs.IsEmpty() {
+					didHit, hitInfo := marchAlongRay(camera.GenerateRayForPixelCenter(x, y, ImageResolutionX, ImageResolutionY))
+					renderResult.Aovs.Record(x, row, didHit, hitInfo, calculateAovOcclusion(&renderResult.Aovs, didHit, hitInfo))
+				}
+
+				continue
+			}
+
 			ray := camera.GenerateRayForPixelCenter(x, y, ImageResolutionX, ImageResolutionY)
-			pixelColor := Color{Red: 0, Green: 0, Blue: 0, Alpha: 0}
+			var pixelColor Vec3
 
-			didHit, hitInfo := camera.MarchAlongRay(ray, scene, RayStepSize)
+			didHit, hitInfo := marchAlongRay(ray)
+			renderResult.StepCount += hitInfo.StepCount
+
+			occlusion := 1.0
 
 			if didHit {
-				pixelColor = calculatePixelColor(hitInfo, camera)
+				if UseAmbientOcclusion {
+					occlusion = scene.AmbientOcclusion(hitInfo.Point, hitInfo.Normal, ambientOcclusionSettings)
+				}
+
+				pixelColor = calculatePixelColor(hitInfo, ray, occlusion)
+			} else {
+				pixelColor = scene.ApplyFog(ray, CameraFarPlane, scene.GetBackground(ray.Direction))
 			}
 
-			renderResult.Pixels[pixelIndex] = pixelColor
-			pixelIndex++
+			// Without ambient occlusion in the shading, the AOV still needs the actual occlusion
+			if !UseAmbientOcclusion {
+				occlusion = calculateAovOcclusion(&renderResult.Aovs, didHit, hitInfo)
+			}
+
+			renderResult.Pixels.SetPixel(x, row, pixelColor, 1.0)
+			renderResult.Aovs.Record(x, row, didHit, hitInfo, occlusion)
 		}
 	}
 
@@ -148,7 +427,8 @@ func waitForWorkers(waitGroup *sync.WaitGroup, finishedWorkQueue chan<- RenderRe
 func main() {
 	defer trackTime(time.Now(), "Render")
 
-	image := NewPngImage(ImageResolutionX, ImageResolutionY, ImageFileName)
+	framebuffer := NewFramebuffer(ImageResolutionX, ImageResolutionY)
+	aovBuffers := NewAovBuffers(ImageResolutionX, ImageResolutionY, aovs...)
 
 	// Start all workers
 	waitGroup := sync.WaitGroup{}
@@ -185,17 +465,53 @@ func main() {
 	waitForWorkers(&waitGroup, finishedWorkQueue)
 
 	// Keep reading render results from the queue as the worker GoRoutines slowly finish their work
+	totalStepCount := 0
 	for result := range finishedWorkQueue {
-		pixelIndex := 0
+		totalStepCount += result.StepCount
 
-		// Save each render chunk in the final output file
-		for y := result.StartRow; y < result.StartRow+result.RowCount; y++ {
-			for x := 0; x < ImageResolutionX; x++ {
-				image.SetPixelColor(x, y, result.Pixels[pixelIndex])
-				pixelIndex++
-			}
+		// Place each render chunk in the final framebuffer
+		framebuffer.CopyFrom(&result.Pixels, 0, result.StartRow)
+		aovBuffers.CopyFrom(&result.Aovs, 0, result.StartRow)
+	}
+
+	log.Println("Marched a total of", totalStepCount, "steps, an average of", float64(totalStepCount)/float64(ImageResolutionX*ImageResolutionY), "steps per pixel")
+
+	// The image format is based on the file extension, low dynamic range formats apply the output transform
+	if error := WriteImageFile(ImageFileName, &framebuffer, outputTransform, ImageBitDepth); error != nil {
+		log.Println(error.Error())
+	}
+
+	// AOVs are remapped into [0.0, 1.0] unless the image format can store their values as is, they are data rather
+	// than colors so they are stored without the sRGB encoding
+	for _, aov := range aovBuffers.GetAovs() {
+		fileName := aov.String() + AovFileExtension
+		buffer, _ := aovBuffers.GetBuffer(aov)
+
+		if !IsHighDynamicRangeFile(fileName) {
+			visualization := aov.Visualize(buffer, aovRanges)
+			buffer = &visualization
+		}
+
+		if error := WriteImageFile(fileName, buffer, NewLinearOutputTransform(), ImageBitDepth); error != nil {
+			log.Println(error.Error())
 		}
 	}
 
-	image.WritePngToFile()
+	// The OpenEXR image keeps the linear radiance values before the output transform is applied
+	if WriteExrImage {
+		exrImage := NewExrImage(ImageResolutionX, ImageResolutionY)
+		if error := exrImage.AddFramebuffer("", &framebuffer, ExrPixelHalf); error != nil {
+			log.Println(error.Error())
+			return
+		}
+
+		if error := aovBuffers.AddToExrImage(&exrImage); error != nil {
+			log.Println(error.Error())
+			return
+		}
+
+		if error := exrImage.WriteExrToFile(ExrFileName, ExrCompressionZip); error != nil {
+			log.Println(error.Error())
+		}
+	}
 }
@@ -0,0 +1,105 @@
+package mathematics
+
+// Row-major 3x3 matrix
+type Mat3 [3][3]float64
+
+// Create a new identity matrix
+func IdentityMat3() Mat3 {
+	return Mat3{
+		{1.0, 0.0, 0.0},
+		{0.0, 1.0, 0.0},
+		{0.0, 0.0, 1.0},
+	}
+}
+
+// Create a rotation matrix from a unit quaternion
+func Mat3FromQuaternion(q Quaternion) Mat3 {
+	xx, yy, zz := q.X*q.X, q.Y*q.Y, q.Z*q.Z
+	xy, xz, yz := q.X*q.Y, q.X*q.Z, q.Y*q.Z
+	wx, wy, wz := q.W*q.X, q.W*q.Y, q.W*q.Z
+
+	return Mat3{
+		{1.0 - 2.0*(yy+zz), 2.0 * (xy - wz), 2.0 * (xz + wy)},
+		{2.0 * (xy + wz), 1.0 - 2.0*(xx+zz), 2.0 * (yz - wx)},
+		{2.0 * (xz - wy), 2.0 * (yz + wx), 1.0 - 2.0*(xx+yy)},
+	}
+}
+
+// Multiply a matrix with a column vector
+func MultiplyMat3Vec3(m Mat3, v Vec3) Vec3 {
+	return Vec3{
+		m[0][0]*v.X + m[0][1]*v.Y + m[0][2]*v.Z,
+		m[1][0]*v.X + m[1][1]*v.Y + m[1][2]*v.Z,
+		m[2][0]*v.X + m[2][1]*v.Y + m[2][2]*v.Z,
+	}
+}
+
+// Returns the transposed copy of a matrix
+func TransposeMat3(m Mat3) Mat3 {
+	return Mat3{
+		{m[0][0], m[1][0], m[2][0]},
+		{m[0][1], m[1][1], m[2][1]},
+		{m[0][2], m[1][2], m[2][2]},
+	}
+}
+
+// Multiply this matrix with another matrix
+func (m *Mat3) Multiply(other Mat3) {
+	*m = MultiplyMat3(*m, other)
+}
+
+// Transpose this matrix
+func (m *Mat3) Transpose() {
+	*m = TransposeMat3(*m)
+}
+
+// Multiply two matrices, the resulting matrix applies transformation b first and transformation a second
+func MultiplyMat3(a Mat3, b Mat3) Mat3 {
+	result := Mat3{}
+
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 3; column++ {
+			for i := 0; i < 3; i++ {
+				result[row][column] += a[row][i] * b[i][column]
+			}
+		}
+	}
+
+	return result
+}
+
+// Calculate the determinant of a matrix
+func (m *Mat3) Determinant() float64 {
+	return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+}
+
+// Calculate the inverse of a matrix, returns false if the matrix is singular and cannot be inverted
+func InverseMat3(m Mat3) (Mat3, bool) {
+	determinant := m.Determinant()
+
+	if determinant == 0.0 {
+		return Mat3{}, false
+	}
+
+	inverseDeterminant := 1.0 / determinant
+
+	return Mat3{
+		{
+			(m[1][1]*m[2][2] - m[1][2]*m[2][1]) * inverseDeterminant,
+			(m[0][2]*m[2][1] - m[0][1]*m[2][2]) * inverseDeterminant,
+			(m[0][1]*m[1][2] - m[0][2]*m[1][1]) * inverseDeterminant,
+		},
+		{
+			(m[1][2]*m[2][0] - m[1][0]*m[2][2]) * inverseDeterminant,
+			(m[0][0]*m[2][2] - m[0][2]*m[2][0]) * inverseDeterminant,
+			(m[0][2]*m[1][0] - m[0][0]*m[1][2]) * inverseDeterminant,
+		},
+		{
+			(m[1][0]*m[2][1] - m[1][1]*m[2][0]) * inverseDeterminant,
+			(m[0][1]*m[2][0] - m[0][0]*m[2][1]) * inverseDeterminant,
+			(m[0][0]*m[1][1] - m[0][1]*m[1][0]) * inverseDeterminant,
+		},
+	}, true
+}
@@ -0,0 +1,111 @@
+package mathematics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIdentityMat3(t *testing.T) {
+	v := Vec3{1.0, 2.0, 3.0}
+	result := MultiplyMat3Vec3(IdentityMat3(), v)
+
+	if result != v {
+		t.Fatalf("Identity failure: expected %v but got %v", v, result)
+	}
+}
+
+func TestMat3FromQuaternion(t *testing.T) {
+	q := QuaternionFromAxisAngle(Vec3{1.0, 2.0, 3.0}, 0.75)
+	v := Vec3{-1.0, 4.0, 2.0}
+	expected := RotateVec3(q, v)
+	result := MultiplyMat3Vec3(Mat3FromQuaternion(q), v)
+
+	if !vec3NearlyEqual(result, expected) {
+		t.Fatalf("Matrix from quaternion failure: expected %v but got %v", expected, result)
+	}
+}
+
+func TestTransposeMat3(t *testing.T) {
+	m := Mat3{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}
+	result := TransposeMat3(m)
+
+	if result[0][1] != 4.0 || result[1][0] != 2.0 || result[2][0] != 3.0 || result[1][1] != 5.0 {
+		t.Fatalf("Transpose failure: transposed %v into %v", m, result)
+	}
+}
+
+func TestTransposeMat3InvertsRotation(t *testing.T) {
+	rotation := Mat3FromQuaternion(QuaternionFromAxisAngle(Vec3{0.0, 1.0, 0.0}, math.Pi/3.0))
+	v := Vec3{1.0, 2.0, 3.0}
+	result := MultiplyMat3Vec3(TransposeMat3(rotation), MultiplyMat3Vec3(rotation, v))
+
+	if !vec3NearlyEqual(result, v) {
+		t.Fatalf("Transpose failure: expected %v but got %v", v, result)
+	}
+}
+
+func TestMultiplyMat3(t *testing.T) {
+	a := Mat3{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}
+	b := Mat3{{9.0, 8.0, 7.0}, {6.0, 5.0, 4.0}, {3.0, 2.0, 1.0}}
+	expected := Mat3{{30.0, 24.0, 18.0}, {84.0, 69.0, 54.0}, {138.0, 114.0, 90.0}}
+	result := MultiplyMat3(a, b)
+
+	if result != expected {
+		t.Fatalf("Multiplication failure: expected %v but got %v", expected, result)
+	}
+}
+
+func TestMultiplyWithMat3(t *testing.T) {
+	a := Mat3{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}
+	a.Multiply(IdentityMat3())
+
+	if a != (Mat3{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}) {
+		t.Fatalf("Multiplication failure: multiplying by identity changed the matrix into %v", a)
+	}
+}
+
+func TestTransposeInPlaceMat3(t *testing.T) {
+	m := Mat3{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}
+	m.Transpose()
+
+	if m != TransposeMat3(Mat3{{1.0, 2.0, 3.0}, {4.0, 5.0, 6.0}, {7.0, 8.0, 9.0}}) {
+		t.Fatalf("Transpose failure: got %v", m)
+	}
+}
+
+func TestDeterminantMat3(t *testing.T) {
+	m := Mat3{{2.0, 0.0, 1.0}, {1.0, 3.0, 2.0}, {1.0, 1.0, 2.0}}
+	determinant := m.Determinant()
+
+	if determinant != 6.0 {
+		t.Fatalf("Determinant failure: expected 6.0 but got %f", determinant)
+	}
+}
+
+func TestInverseMat3(t *testing.T) {
+	m := Mat3{{2.0, 0.0, 1.0}, {1.0, 3.0, 2.0}, {1.0, 1.0, 2.0}}
+	inverse, ok := InverseMat3(m)
+	result := MultiplyMat3(m, inverse)
+	identity := IdentityMat3()
+
+	if !ok {
+		t.Fatalf("Inverse failure: %v should be invertible", m)
+	}
+
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 3; column++ {
+			if math.Abs(result[row][column]-identity[row][column]) > epsilon {
+				t.Fatalf("Inverse failure: %v multiplied by its inverse is %v", m, result)
+			}
+		}
+	}
+}
+
+func TestInverseMat3Singular(t *testing.T) {
+	m := Mat3{{1.0, 2.0, 3.0}, {2.0, 4.0, 6.0}, {1.0, 1.0, 1.0}}
+	_, ok := InverseMat3(m)
+
+	if ok {
+		t.Fatalf("Inverse failure: %v is singular and should not be invertible", m)
+	}
+}
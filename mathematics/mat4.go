@@ -0,0 +1,224 @@
+package mathematics
+
+import "math"
+
+// Row-major 4x4 matrix, vectors are treated as column vectors
+type Mat4 [4][4]float64
+
+// Create a new identity matrix
+func IdentityMat4() Mat4 {
+	return Mat4{
+		{1.0, 0.0, 0.0, 0.0},
+		{0.0, 1.0, 0.0, 0.0},
+		{0.0, 0.0, 1.0, 0.0},
+		{0.0, 0.0, 0.0, 1.0},
+	}
+}
+
+// Create a translation matrix
+func TranslationMat4(translation Vec3) Mat4 {
+	m := IdentityMat4()
+	m[0][3] = translation.X
+	m[1][3] = translation.Y
+	m[2][3] = translation.Z
+	return m
+}
+
+// Create a scale matrix
+func ScaleMat4(scale Vec3) Mat4 {
+	m := IdentityMat4()
+	m[0][0] = scale.X
+	m[1][1] = scale.Y
+	m[2][2] = scale.Z
+	return m
+}
+
+// Create a rotation matrix from a unit quaternion
+func Mat4FromQuaternion(q Quaternion) Mat4 {
+	return Mat4FromMat3(Mat3FromQuaternion(q))
+}
+
+// Create a 4x4 matrix from the 3x3 matrix in its upper left corner
+func Mat4FromMat3(m Mat3) Mat4 {
+	return Mat4{
+		{m[0][0], m[0][1], m[0][2], 0.0},
+		{m[1][0], m[1][1], m[1][2], 0.0},
+		{m[2][0], m[2][1], m[2][2], 0.0},
+		{0.0, 0.0, 0.0, 1.0},
+	}
+}
+
+// Create a right-handed view matrix for a camera at the eye position looking at the target
+//
+// Reference: https://www.khronos.org/registry/OpenGL-Refpages/gl2.1/xhtml/gluLookAt.xml
+func LookAtMat4(eye Vec3, target Vec3, up Vec3) Mat4 {
+	forward := Normalize(Sub(target, eye))
+	right := Normalize(Cross(forward, up))
+	trueUp := Cross(right, forward)
+
+	return Mat4{
+		{right.X, right.Y, right.Z, -Dot(right, eye)},
+		{trueUp.X, trueUp.Y, trueUp.Z, -Dot(trueUp, eye)},
+		{-forward.X, -forward.Y, -forward.Z, Dot(forward, eye)},
+		{0.0, 0.0, 0.0, 1.0},
+	}
+}
+
+// Create a right-handed perspective projection matrix that maps depth onto [-1.0, 1.0]
+//
+//	FieldOfView : vertical field of view in radians
+//	AspectRatio : width of the viewport divided by its height
+//	Near        : distance to the near plane
+//	Far         : distance to the far plane
+//
+// Reference: https://www.khronos.org/registry/OpenGL-Refpages/gl2.1/xhtml/gluPerspective.xml
+func PerspectiveMat4(fieldOfView float64, aspectRatio float64, near float64, far float64) Mat4 {
+	f := 1.0 / math.Tan(fieldOfView*0.5)
+
+	return Mat4{
+		{f / aspectRatio, 0.0, 0.0, 0.0},
+		{0.0, f, 0.0, 0.0},
+		{0.0, 0.0, (far + near) / (near - far), (2.0 * far * near) / (near - far)},
+		{0.0, 0.0, -1.0, 0.0},
+	}
+}
+
+// Multiply this matrix with another matrix
+func (m *Mat4) Multiply(other Mat4) {
+	*m = MultiplyMat4(*m, other)
+}
+
+// Transpose this matrix
+func (m *Mat4) Transpose() {
+	*m = TransposeMat4(*m)
+}
+
+// Multiply two matrices, the resulting matrix applies transformation b first and transformation a second
+func MultiplyMat4(a Mat4, b Mat4) Mat4 {
+	result := Mat4{}
+
+	for row := 0; row < 4; row++ {
+		for column := 0; column < 4; column++ {
+			for i := 0; i < 4; i++ {
+				result[row][column] += a[row][i] * b[i][column]
+			}
+		}
+	}
+
+	return result
+}
+
+// Transform a point by a matrix, the result is divided by w to support projection matrices
+func MultiplyMat4Point(m Mat4, point Vec3) Vec3 {
+	x := m[0][0]*point.X + m[0][1]*point.Y + m[0][2]*point.Z + m[0][3]
+	y := m[1][0]*point.X + m[1][1]*point.Y + m[1][2]*point.Z + m[1][3]
+	z := m[2][0]*point.X + m[2][1]*point.Y + m[2][2]*point.Z + m[2][3]
+	w := m[3][0]*point.X + m[3][1]*point.Y + m[3][2]*point.Z + m[3][3]
+
+	if w != 0.0 && w != 1.0 {
+		return Vec3{x / w, y / w, z / w}
+	}
+
+	return Vec3{x, y, z}
+}
+
+// Transform a direction by a matrix, directions are not affected by translation
+func MultiplyMat4Direction(m Mat4, direction Vec3) Vec3 {
+	return Vec3{
+		m[0][0]*direction.X + m[0][1]*direction.Y + m[0][2]*direction.Z,
+		m[1][0]*direction.X + m[1][1]*direction.Y + m[1][2]*direction.Z,
+		m[2][0]*direction.X + m[2][1]*direction.Y + m[2][2]*direction.Z,
+	}
+}
+
+// Returns the transposed copy of a matrix
+func TransposeMat4(m Mat4) Mat4 {
+	result := Mat4{}
+
+	for row := 0; row < 4; row++ {
+		for column := 0; column < 4; column++ {
+			result[row][column] = m[column][row]
+		}
+	}
+
+	return result
+}
+
+// Calculate the 2x2 sub-determinants of the upper and lower halves of a matrix, these are shared between the
+// determinant and the inverse
+//
+// Reference: https://www.geometrictools.com/Documentation/LaplaceExpansionTheorem.pdf
+func (m *Mat4) subDeterminants() ([6]float64, [6]float64) {
+	upper := [6]float64{
+		m[0][0]*m[1][1] - m[1][0]*m[0][1],
+		m[0][0]*m[1][2] - m[1][0]*m[0][2],
+		m[0][0]*m[1][3] - m[1][0]*m[0][3],
+		m[0][1]*m[1][2] - m[1][1]*m[0][2],
+		m[0][1]*m[1][3] - m[1][1]*m[0][3],
+		m[0][2]*m[1][3] - m[1][2]*m[0][3],
+	}
+
+	lower := [6]float64{
+		m[2][0]*m[3][1] - m[3][0]*m[2][1],
+		m[2][0]*m[3][2] - m[3][0]*m[2][2],
+		m[2][0]*m[3][3] - m[3][0]*m[2][3],
+		m[2][1]*m[3][2] - m[3][1]*m[2][2],
+		m[2][1]*m[3][3] - m[3][1]*m[2][3],
+		m[2][2]*m[3][3] - m[3][2]*m[2][3],
+	}
+
+	return upper, lower
+}
+
+// Calculate the determinant of a matrix
+func (m *Mat4) Determinant() float64 {
+	s, c := m.subDeterminants()
+	return s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+}
+
+// Calculate the inverse of a matrix, returns false if the matrix is singular and cannot be inverted
+func InverseMat4(m Mat4) (Mat4, bool) {
+	s, c := m.subDeterminants()
+	determinant := s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+
+	if determinant == 0.0 {
+		return Mat4{}, false
+	}
+
+	inverseDeterminant := 1.0 / determinant
+
+	result := Mat4{
+		{
+			m[1][1]*c[5] - m[1][2]*c[4] + m[1][3]*c[3],
+			-m[0][1]*c[5] + m[0][2]*c[4] - m[0][3]*c[3],
+			m[3][1]*s[5] - m[3][2]*s[4] + m[3][3]*s[3],
+			-m[2][1]*s[5] + m[2][2]*s[4] - m[2][3]*s[3],
+		},
+		{
+			-m[1][0]*c[5] + m[1][2]*c[2] - m[1][3]*c[1],
+			m[0][0]*c[5] - m[0][2]*c[2] + m[0][3]*c[1],
+			-m[3][0]*s[5] + m[3][2]*s[2] - m[3][3]*s[1],
+			m[2][0]*s[5] - m[2][2]*s[2] + m[2][3]*s[1],
+		},
+		{
+			m[1][0]*c[4] - m[1][1]*c[2] + m[1][3]*c[0],
+			-m[0][0]*c[4] + m[0][1]*c[2] - m[0][3]*c[0],
+			m[3][0]*s[4] - m[3][1]*s[2] + m[3][3]*s[0],
+			-m[2][0]*s[4] + m[2][1]*s[2] - m[2][3]*s[0],
+		},
+		{
+			-m[1][0]*c[3] + m[1][1]*c[1] - m[1][2]*c[0],
+			m[0][0]*c[3] - m[0][1]*c[1] + m[0][2]*c[0],
+			-m[3][0]*s[3] + m[3][1]*s[1] - m[3][2]*s[0],
+			m[2][0]*s[3] - m[2][1]*s[1] + m[2][2]*s[0],
+		},
+	}
+
+	for row := 0; row < 4; row++ {
+		for column := 0; column < 4; column++ {
+			result[row][column] *= inverseDeterminant
+		}
+	}
+
+	return result, true
+}
@@ -0,0 +1,156 @@
+package mathematics
+
+import (
+	"math"
+	"testing"
+)
+
+func mat4NearlyEqual(a Mat4, b Mat4) bool {
+	for row := 0; row < 4; row++ {
+		for column := 0; column < 4; column++ {
+			if math.Abs(a[row][column]-b[row][column]) > epsilon {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func TestIdentityMat4(t *testing.T) {
+	v := Vec3{1.0, 2.0, 3.0}
+	result := MultiplyMat4Point(IdentityMat4(), v)
+
+	if result != v {
+		t.Fatalf("Identity failure: expected %v but got %v", v, result)
+	}
+}
+
+func TestTranslationMat4(t *testing.T) {
+	m := TranslationMat4(Vec3{1.0, 2.0, 3.0})
+	point := MultiplyMat4Point(m, Vec3{1.0, 1.0, 1.0})
+	direction := MultiplyMat4Direction(m, Vec3{1.0, 1.0, 1.0})
+
+	if point != (Vec3{2.0, 3.0, 4.0}) {
+		t.Fatalf("Translation failure: expected {2 3 4} but got %v", point)
+	}
+
+	if direction != (Vec3{1.0, 1.0, 1.0}) {
+		t.Fatalf("Translation failure: directions should not be translated but got %v", direction)
+	}
+}
+
+func TestScaleMat4(t *testing.T) {
+	result := MultiplyMat4Point(ScaleMat4(Vec3{2.0, 3.0, 4.0}), Vec3{1.0, 1.0, 1.0})
+
+	if result != (Vec3{2.0, 3.0, 4.0}) {
+		t.Fatalf("Scale failure: expected {2 3 4} but got %v", result)
+	}
+}
+
+func TestMat4FromQuaternion(t *testing.T) {
+	q := QuaternionFromAxisAngle(Vec3{0.0, 1.0, 0.0}, math.Pi/2.0)
+	result := MultiplyMat4Direction(Mat4FromQuaternion(q), Vec3{1.0, 0.0, 0.0})
+
+	if !vec3NearlyEqual(result, Vec3{0.0, 0.0, -1.0}) {
+		t.Fatalf("Matrix from quaternion failure: expected {0 0 -1} but got %v", result)
+	}
+}
+
+func TestMultiplyMat4(t *testing.T) {
+	translation := TranslationMat4(Vec3{1.0, 0.0, 0.0})
+	scale := ScaleMat4(Vec3{2.0, 2.0, 2.0})
+	result := MultiplyMat4Point(MultiplyMat4(translation, scale), Vec3{1.0, 1.0, 1.0})
+
+	// Scale is applied first, translation second
+	if result != (Vec3{3.0, 2.0, 2.0}) {
+		t.Fatalf("Multiplication failure: expected {3 2 2} but got %v", result)
+	}
+}
+
+func TestMultiplyWithMat4(t *testing.T) {
+	m := TranslationMat4(Vec3{1.0, 2.0, 3.0})
+	m.Multiply(IdentityMat4())
+
+	if m != TranslationMat4(Vec3{1.0, 2.0, 3.0}) {
+		t.Fatalf("Multiplication failure: multiplying by identity changed the matrix into %v", m)
+	}
+}
+
+func TestTransposeMat4(t *testing.T) {
+	m := TranslationMat4(Vec3{1.0, 2.0, 3.0})
+	m.Transpose()
+
+	if m[3][0] != 1.0 || m[3][1] != 2.0 || m[3][2] != 3.0 || m[0][3] != 0.0 {
+		t.Fatalf("Transpose failure: got %v", m)
+	}
+}
+
+func TestDeterminantMat4(t *testing.T) {
+	m := Mat4{
+		{1.0, 0.0, 2.0, -1.0},
+		{3.0, 0.0, 0.0, 5.0},
+		{2.0, 1.0, 4.0, -3.0},
+		{1.0, 0.0, 5.0, 0.0},
+	}
+	determinant := m.Determinant()
+
+	if math.Abs(determinant-30.0) > epsilon {
+		t.Fatalf("Determinant failure: expected 30.0 but got %f", determinant)
+	}
+}
+
+func TestInverseMat4(t *testing.T) {
+	m := Mat4{
+		{1.0, 0.0, 2.0, -1.0},
+		{3.0, 0.0, 0.0, 5.0},
+		{2.0, 1.0, 4.0, -3.0},
+		{1.0, 0.0, 5.0, 0.0},
+	}
+	inverse, ok := InverseMat4(m)
+
+	if !ok {
+		t.Fatalf("Inverse failure: %v should be invertible", m)
+	}
+
+	if !mat4NearlyEqual(MultiplyMat4(m, inverse), IdentityMat4()) || !mat4NearlyEqual(MultiplyMat4(inverse, m), IdentityMat4()) {
+		t.Fatalf("Inverse failure: %v multiplied by its inverse %v is not the identity", m, inverse)
+	}
+}
+
+func TestInverseMat4Singular(t *testing.T) {
+	_, ok := InverseMat4(ScaleMat4(Vec3{1.0, 0.0, 1.0}))
+
+	if ok {
+		t.Fatalf("Inverse failure: singular matrix should not be invertible")
+	}
+}
+
+func TestLookAtMat4(t *testing.T) {
+	eye := Vec3{0.0, 0.0, 5.0}
+	view := LookAtMat4(eye, Vec3{0.0, 0.0, 0.0}, Vec3{0.0, 1.0, 0.0})
+
+	// The eye ends up on the origin and the target straight ahead along -Z
+	if !vec3NearlyEqual(MultiplyMat4Point(view, eye), Vec3{0.0, 0.0, 0.0}) {
+		t.Fatalf("Look-at failure: eye should map onto the origin")
+	}
+
+	if !vec3NearlyEqual(MultiplyMat4Point(view, Vec3{0.0, 0.0, 0.0}), Vec3{0.0, 0.0, -5.0}) {
+		t.Fatalf("Look-at failure: target should map onto {0 0 -5}")
+	}
+}
+
+func TestPerspectiveMat4(t *testing.T) {
+	projection := PerspectiveMat4(math.Pi/2.0, 1.0, 1.0, 10.0)
+	near := MultiplyMat4Point(projection, Vec3{0.0, 0.0, -1.0})
+	far := MultiplyMat4Point(projection, Vec3{0.0, 0.0, -10.0})
+	corner := MultiplyMat4Point(projection, Vec3{2.0, 2.0, -2.0})
+
+	if math.Abs(near.Z+1.0) > epsilon || math.Abs(far.Z-1.0) > epsilon {
+		t.Fatalf("Perspective failure: near and far planes should map onto -1 and 1 but got %f and %f", near.Z, far.Z)
+	}
+
+	if math.Abs(corner.X-1.0) > epsilon || math.Abs(corner.Y-1.0) > epsilon {
+		t.Fatalf("Perspective failure: expected the corner of the frustum to map onto {1 1} but got %v", corner)
+	}
+}
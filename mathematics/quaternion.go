@@ -0,0 +1,119 @@
+package mathematics
+
+import "math"
+
+// Quaternion with a real part W and imaginary parts X, Y, and Z. Unit quaternions represent rotations in 3D
+// space, while arbitrary quaternions can be used as points in 4D space.
+type Quaternion struct {
+	W, X, Y, Z float64
+}
+
+// Create a quaternion that does not rotate at all
+func IdentityQuaternion() Quaternion {
+	return Quaternion{1.0, 0.0, 0.0, 0.0}
+}
+
+// Create a quaternion that rotates around an axis by an angle in radians
+func QuaternionFromAxisAngle(axis Vec3, angle float64) Quaternion {
+	axis = Normalize(axis)
+	sin, cos := math.Sincos(angle * 0.5)
+
+	return Quaternion{cos, axis.X * sin, axis.Y * sin, axis.Z * sin}
+}
+
+// Add two quaternions together
+func AddQuaternion(a Quaternion, b Quaternion) Quaternion {
+	return Quaternion{a.W + b.W, a.X + b.X, a.Y + b.Y, a.Z + b.Z}
+}
+
+// Multiply two quaternions, the resulting quaternion applies rotation b first and rotation a second
+func MultiplyQuaternion(a Quaternion, b Quaternion) Quaternion {
+	return Quaternion{
+		a.W*b.W - a.X*b.X - a.Y*b.Y - a.Z*b.Z,
+		a.W*b.X + a.X*b.W + a.Y*b.Z - a.Z*b.Y,
+		a.W*b.Y - a.X*b.Z + a.Y*b.W + a.Z*b.X,
+		a.W*b.Z + a.X*b.Y - a.Y*b.X + a.Z*b.W,
+	}
+}
+
+// Returns the conjugate of a quaternion, for unit quaternions this is the inverse rotation
+func Conjugate(q Quaternion) Quaternion {
+	return Quaternion{q.W, -q.X, -q.Y, -q.Z}
+}
+
+// Rotate a vector using a unit quaternion
+//
+// Reference: https://fgiesen.wordpress.com/2019/02/09/rotating-a-single-vector-using-a-quaternion/
+func RotateVec3(q Quaternion, v Vec3) Vec3 {
+	axis := Vec3{q.X, q.Y, q.Z}
+	t := MultiplyScalar(Cross(axis, v), 2.0)
+
+	return AddAll(v, MultiplyScalar(t, q.W), Cross(axis, t))
+}
+
+// Multiply this quaternion with another quaternion
+func (q *Quaternion) Multiply(other Quaternion) {
+	*q = MultiplyQuaternion(*q, other)
+}
+
+// Normalize this quaternion
+func (q *Quaternion) Normalize() {
+	*q = NormalizeQuaternion(*q)
+}
+
+// Calculate the length of a quaternion
+func (q *Quaternion) Length() float64 {
+	return math.Sqrt(DotQuaternion(*q, *q))
+}
+
+// Calculate the dot product scalar value of two quaternions
+func DotQuaternion(a Quaternion, b Quaternion) float64 {
+	return a.W*b.W + a.X*b.X + a.Y*b.Y + a.Z*b.Z
+}
+
+// Normalize a quaternion
+func NormalizeQuaternion(q Quaternion) Quaternion {
+	length := q.Length()
+	return Quaternion{q.W / length, q.X / length, q.Y / length, q.Z / length}
+}
+
+// Spherically interpolate between two unit quaternions, always taking the shortest path
+//
+// Reference: https://en.wikipedia.org/wiki/Slerp
+func Slerp(a Quaternion, b Quaternion, t float64) Quaternion {
+	cosTheta := DotQuaternion(a, b)
+
+	// Flip one of the rotations to take the shortest path
+	if cosTheta < 0.0 {
+		b = Quaternion{-b.W, -b.X, -b.Y, -b.Z}
+		cosTheta = -cosTheta
+	}
+
+	weightA := 1.0 - t
+	weightB := t
+
+	// Fall back to linear interpolation when the rotations are nearly identical to avoid dividing by zero
+	if cosTheta < 0.9995 {
+		theta := math.Acos(cosTheta)
+		sinTheta := math.Sin(theta)
+		weightA = math.Sin((1.0-t)*theta) / sinTheta
+		weightB = math.Sin(t*theta) / sinTheta
+	}
+
+	return NormalizeQuaternion(Quaternion{
+		a.W*weightA + b.W*weightB,
+		a.X*weightA + b.X*weightB,
+		a.Y*weightA + b.Y*weightB,
+		a.Z*weightA + b.Z*weightB,
+	})
+}
+
+// Convert a unit quaternion into a 4x4 rotation matrix
+func (q *Quaternion) ToMat4() Mat4 {
+	return Mat4FromQuaternion(*q)
+}
+
+// Convert a unit quaternion into a 3x3 rotation matrix
+func (q *Quaternion) ToMat3() Mat3 {
+	return Mat3FromQuaternion(*q)
+}
@@ -0,0 +1,147 @@
+package mathematics
+
+import (
+	"math"
+	"testing"
+)
+
+func vec3NearlyEqual(a Vec3, b Vec3) bool {
+	return math.Abs(a.X-b.X) < epsilon && math.Abs(a.Y-b.Y) < epsilon && math.Abs(a.Z-b.Z) < epsilon
+}
+
+func TestQuaternionFromAxisAngle(t *testing.T) {
+	q := QuaternionFromAxisAngle(Vec3{0.0, 2.0, 0.0}, math.Pi)
+
+	if math.Abs(q.W) > epsilon || math.Abs(q.Y-1.0) > epsilon {
+		t.Fatalf("Axis-angle failure: expected {0 0 1 0} but got %v", q)
+	}
+}
+
+func TestRotateVec3(t *testing.T) {
+	q := QuaternionFromAxisAngle(Vec3{0.0, 0.0, 1.0}, math.Pi/2.0)
+	result := RotateVec3(q, Vec3{1.0, 0.0, 0.0})
+
+	if !vec3NearlyEqual(result, Vec3{0.0, 1.0, 0.0}) {
+		t.Fatalf("Rotation failure: expected {0 1 0} but got %v", result)
+	}
+}
+
+func TestRotateVec3Identity(t *testing.T) {
+	v := Vec3{1.0, 2.0, 3.0}
+	result := RotateVec3(IdentityQuaternion(), v)
+
+	if !vec3NearlyEqual(result, v) {
+		t.Fatalf("Rotation failure: identity rotation changed %v into %v", v, result)
+	}
+}
+
+func TestAddQuaternion(t *testing.T) {
+	result := AddQuaternion(Quaternion{1.0, 2.0, 3.0, 4.0}, Quaternion{10.0, 20.0, 30.0, 40.0})
+
+	if result != (Quaternion{11.0, 22.0, 33.0, 44.0}) {
+		t.Fatalf("Addition failure: expected {11 22 33 44} but got %v", result)
+	}
+}
+
+func TestMultiplyQuaternion(t *testing.T) {
+	a := QuaternionFromAxisAngle(Vec3{0.0, 0.0, 1.0}, math.Pi/2.0)
+	b := QuaternionFromAxisAngle(Vec3{1.0, 0.0, 0.0}, math.Pi/2.0)
+	result := RotateVec3(MultiplyQuaternion(a, b), Vec3{0.0, 1.0, 0.0})
+
+	// Rotating {0 1 0} around X gives {0 0 1}, rotating that around Z leaves it unchanged
+	if !vec3NearlyEqual(result, Vec3{0.0, 0.0, 1.0}) {
+		t.Fatalf("Quaternion multiplication failure: expected {0 0 1} but got %v", result)
+	}
+}
+
+func TestConjugate(t *testing.T) {
+	q := QuaternionFromAxisAngle(Vec3{1.0, 1.0, 0.0}, 1.0)
+	v := Vec3{3.0, -2.0, 5.0}
+	result := RotateVec3(Conjugate(q), RotateVec3(q, v))
+
+	if !vec3NearlyEqual(result, v) {
+		t.Fatalf("Conjugate failure: expected %v but got %v", v, result)
+	}
+}
+
+func TestMultiplyWithQuaternion(t *testing.T) {
+	q := QuaternionFromAxisAngle(Vec3{0.0, 0.0, 1.0}, math.Pi/4.0)
+	q.Multiply(QuaternionFromAxisAngle(Vec3{0.0, 0.0, 1.0}, math.Pi/4.0))
+	result := RotateVec3(q, Vec3{1.0, 0.0, 0.0})
+
+	if !vec3NearlyEqual(result, Vec3{0.0, 1.0, 0.0}) {
+		t.Fatalf("Quaternion multiplication failure: expected {0 1 0} but got %v", result)
+	}
+}
+
+func TestNormalizeQuaternion(t *testing.T) {
+	q := Quaternion{2.0, 0.0, 0.0, 0.0}
+	q.Normalize()
+	length := q.Length()
+
+	if math.Abs(length-1.0) > epsilon {
+		t.Fatalf("Normalize failure: expected 1.0 but got %f", length)
+	}
+}
+
+func TestDotQuaternion(t *testing.T) {
+	result := DotQuaternion(Quaternion{1.0, 2.0, 3.0, 4.0}, Quaternion{5.0, 6.0, 7.0, 8.0})
+
+	if result != 70.0 {
+		t.Fatalf("Dot product failure: expected 70.0 but got %f", result)
+	}
+}
+
+func TestSlerp(t *testing.T) {
+	a := IdentityQuaternion()
+	b := QuaternionFromAxisAngle(Vec3{0.0, 0.0, 1.0}, math.Pi/2.0)
+	result := RotateVec3(Slerp(a, b, 0.5), Vec3{1.0, 0.0, 0.0})
+	expected := Vec3{math.Sqrt2 / 2.0, math.Sqrt2 / 2.0, 0.0}
+
+	if !vec3NearlyEqual(result, expected) {
+		t.Fatalf("Slerp failure: expected %v but got %v", expected, result)
+	}
+}
+
+func TestSlerpEndpoints(t *testing.T) {
+	a := QuaternionFromAxisAngle(Vec3{1.0, 0.0, 0.0}, 0.3)
+	b := QuaternionFromAxisAngle(Vec3{0.0, 1.0, 0.0}, 1.2)
+	start := Slerp(a, b, 0.0)
+	end := Slerp(a, b, 1.0)
+
+	if math.Abs(DotQuaternion(start, a)-1.0) > epsilon || math.Abs(DotQuaternion(end, b)-1.0) > epsilon {
+		t.Fatalf("Slerp failure: endpoints should match the input rotations")
+	}
+}
+
+func TestSlerpShortestPath(t *testing.T) {
+	a := IdentityQuaternion()
+	b := QuaternionFromAxisAngle(Vec3{0.0, 0.0, 1.0}, math.Pi/2.0)
+	negated := Quaternion{-b.W, -b.X, -b.Y, -b.Z}
+	result := RotateVec3(Slerp(a, negated, 0.5), Vec3{1.0, 0.0, 0.0})
+	expected := Vec3{math.Sqrt2 / 2.0, math.Sqrt2 / 2.0, 0.0}
+
+	if !vec3NearlyEqual(result, expected) {
+		t.Fatalf("Slerp failure: expected %v but got %v", expected, result)
+	}
+}
+
+func TestQuaternionToMat3(t *testing.T) {
+	q := QuaternionFromAxisAngle(Vec3{1.0, 0.0, 0.0}, math.Pi/2.0)
+	m := q.ToMat3()
+	result := MultiplyMat3Vec3(m, Vec3{0.0, 1.0, 0.0})
+
+	if !vec3NearlyEqual(result, Vec3{0.0, 0.0, 1.0}) {
+		t.Fatalf("Quaternion to matrix failure: expected {0 0 1} but got %v", result)
+	}
+}
+
+func TestQuaternionToMat4(t *testing.T) {
+	q := QuaternionFromAxisAngle(Vec3{1.0, 0.0, 0.0}, math.Pi/2.0)
+	m := q.ToMat4()
+	result := MultiplyMat4Direction(m, Vec3{0.0, 1.0, 0.0})
+
+	if !vec3NearlyEqual(result, Vec3{0.0, 0.0, 1.0}) || m[3][3] != 1.0 {
+		t.Fatalf("Quaternion to matrix failure: expected {0 0 1} but got %v", result)
+	}
+}
@@ -0,0 +1,60 @@
+package mathematics
+
+import "math"
+
+// Deterministic pseudo-random number generator, the same seed always results in the same sequence of numbers
+//
+// Reference: https://www.pcg-random.org/
+type Random struct {
+	state uint64
+}
+
+const pcgMultiplier = 6364136223846793005
+const pcgIncrement = 1442695040888963407
+
+// Create a new random number generator from a seed
+func NewRandom(seed uint64) Random {
+	r := Random{0}
+	r.next()
+	r.state += seed
+	r.next()
+	return r
+}
+
+// Create a new random number generator from a combination of values, for example the coordinates of a pixel
+// and the index of a sample, so that each combination gets its own independent sequence
+func NewRandomFromValues(values ...int) Random {
+	seed := uint64(0)
+
+	for _, value := range values {
+		seed = hashUint64(seed ^ uint64(value))
+	}
+
+	return NewRandom(seed)
+}
+
+// Mix the bits of a value so that similar values result in very different hashes
+//
+// Reference: https://xoshiro.di.unimi.it/splitmix64.c
+func hashUint64(value uint64) uint64 {
+	value += 0x9e3779b97f4a7c15
+	value = (value ^ (value >> 30)) * 0xbf58476d1ce4e5b9
+	value = (value ^ (value >> 27)) * 0x94d049bb133111eb
+	return value ^ (value >> 31)
+}
+
+// Advance the generator and return the next 32 random bits
+func (r *Random) next() uint32 {
+	oldState := r.state
+	r.state = oldState*pcgMultiplier + pcgIncrement
+
+	xorShifted := uint32(((oldState >> 18) ^ oldState) >> 27)
+	rotation := uint32(oldState >> 59)
+
+	return (xorShifted >> rotation) | (xorShifted << ((-rotation) & 31))
+}
+
+// Get the next random number in the range [0.0, 1.0)
+func (r *Random) Float64() float64 {
+	return math.Ldexp(float64(r.next()), -32)
+}
@@ -0,0 +1,47 @@
+package mathematics
+
+import "testing"
+
+func TestRandomIsDeterministic(t *testing.T) {
+	a := NewRandom(42)
+	b := NewRandom(42)
+
+	for i := 0; i < 100; i++ {
+		if a.Float64() != b.Float64() {
+			t.Fatalf("Random failure: two generators with the same seed diverged after %d numbers", i)
+		}
+	}
+}
+
+func TestRandomRange(t *testing.T) {
+	r := NewRandom(1)
+	sum := 0.0
+
+	for i := 0; i < 10000; i++ {
+		value := r.Float64()
+		sum += value
+
+		if value < 0.0 || value >= 1.0 {
+			t.Fatalf("Random failure: %f is outside of [0.0, 1.0)", value)
+		}
+	}
+
+	if mean := sum / 10000.0; mean < 0.45 || mean > 0.55 {
+		t.Fatalf("Random failure: expected a mean of roughly 0.5 but got %f", mean)
+	}
+}
+
+func TestNewRandomFromValues(t *testing.T) {
+	a := NewRandomFromValues(10, 20, 0)
+	b := NewRandomFromValues(10, 20, 1)
+	c := NewRandomFromValues(10, 20, 0)
+
+	first := a.Float64()
+	if first == b.Float64() {
+		t.Fatalf("Random failure: different values should result in different sequences")
+	}
+
+	if first != c.Float64() {
+		t.Fatalf("Random failure: identical values should result in identical sequences")
+	}
+}
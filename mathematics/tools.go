@@ -6,3 +6,25 @@ import "math"
 func ClampBetween(value float64, min float64, max float64) float64 {
 	return math.Min(math.Max(min, value), max)
 }
+
+// Linearly interpolate between two values
+func Mix(a float64, b float64, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// Polynomial smooth minimum of two values, the smoothness controls the size of the region where the values blend
+//
+// Reference: https://iquilezles.org/articles/smin/
+func SmoothMin(a float64, b float64, smoothness float64) float64 {
+	if smoothness <= 0.0 {
+		return math.Min(a, b)
+	}
+
+	h := ClampBetween(0.5+0.5*(b-a)/smoothness, 0.0, 1.0)
+	return Mix(b, a, h) - smoothness*h*(1.0-h)
+}
+
+// Polynomial smooth maximum of two values, the smoothness controls the size of the region where the values blend
+func SmoothMax(a float64, b float64, smoothness float64) float64 {
+	return -SmoothMin(-a, -b, smoothness)
+}
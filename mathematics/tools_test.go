@@ -33,3 +33,43 @@ func TestClampBetweenLowerBoundExact(t *testing.T) {
 		t.Fatalf("ClampBetween failed to clamp the value within bounds")
 	}
 }
+
+func TestMix(t *testing.T) {
+	result := Mix(10.0, 20.0, 0.25)
+
+	if result != 12.5 {
+		t.Fatalf("Mix failure: expected 12.5 but got %f", result)
+	}
+}
+
+func TestSmoothMinFarApart(t *testing.T) {
+	result := SmoothMin(1.0, 5.0, 0.5)
+
+	if result != 1.0 {
+		t.Fatalf("SmoothMin failure: values outside of the blend region should not blend, got %f", result)
+	}
+}
+
+func TestSmoothMinBlends(t *testing.T) {
+	result := SmoothMin(1.0, 1.0, 0.5)
+
+	if result >= 1.0 {
+		t.Fatalf("SmoothMin failure: equal values should blend below the minimum, got %f", result)
+	}
+}
+
+func TestSmoothMinNoSmoothness(t *testing.T) {
+	result := SmoothMin(1.0, 1.1, 0.0)
+
+	if result != 1.0 {
+		t.Fatalf("SmoothMin failure: expected 1.0 but got %f", result)
+	}
+}
+
+func TestSmoothMax(t *testing.T) {
+	result := SmoothMax(1.0, 5.0, 0.5)
+
+	if result != 5.0 {
+		t.Fatalf("SmoothMax failure: expected 5.0 but got %f", result)
+	}
+}
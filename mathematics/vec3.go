@@ -58,16 +58,41 @@ func Multiply(a Vec3, b Vec3) Vec3 {
 	return Vec3{a.X * b.X, a.Y * b.Y, a.Z * b.Z}
 }
 
+// Divide a vector by another vector
+func Divide(a Vec3, b Vec3) Vec3 {
+	return Vec3{a.X / b.X, a.Y / b.Y, a.Z / b.Z}
+}
+
 // Calculate the dot product scalar value of two vectors
 func Dot(a Vec3, b Vec3) float64 {
 	return (a.X * b.X) + (a.Y * b.Y) + (a.Z * b.Z)
 }
 
+// Calculate the cross product of two vectors
+func Cross(a Vec3, b Vec3) Vec3 {
+	return Vec3{a.Y*b.Z - a.Z*b.Y, a.Z*b.X - a.X*b.Z, a.X*b.Y - a.Y*b.X}
+}
+
 // Returns a negated copy of the input vector
 func Negate(a Vec3) Vec3 {
 	return Vec3{-a.X, -a.Y, -a.Z}
 }
 
+// Returns a copy of the input vector with the absolute value of each component
+func Abs(a Vec3) Vec3 {
+	return Vec3{math.Abs(a.X), math.Abs(a.Y), math.Abs(a.Z)}
+}
+
+// Returns the largest value of each component of two vectors
+func ComponentMax(a Vec3, b Vec3) Vec3 {
+	return Vec3{math.Max(a.X, b.X), math.Max(a.Y, b.Y), math.Max(a.Z, b.Z)}
+}
+
+// Returns the smallest value of each component of two vectors
+func ComponentMin(a Vec3, b Vec3) Vec3 {
+	return Vec3{math.Min(a.X, b.X), math.Min(a.Y, b.Y), math.Min(a.Z, b.Z)}
+}
+
 // Reflect a vector
 //
 // Reference: https://www.khronos.org/registry/OpenGL-Refpages/gl4/html/reflect.xhtml
@@ -81,6 +106,22 @@ func Reflect(incident Vec3, normal Vec3) Vec3 {
 	return Vec3{reflectX, reflectY, reflectZ}
 }
 
+// Refract a vector through a surface, the normal has to point against the incident vector and eta is the ratio
+// between the indices of refraction on the incident side and the transmitted side. False is returned when the
+// vector is reflected entirely (total internal reflection).
+//
+// Reference: https://www.khronos.org/registry/OpenGL-Refpages/gl4/html/refract.xhtml
+func Refract(incident Vec3, normal Vec3, eta float64) (Vec3, bool) {
+	normalDotIncident := Dot(normal, incident)
+	k := 1.0 - eta*eta*(1.0-normalDotIncident*normalDotIncident)
+
+	if k < 0.0 {
+		return Vec3{}, false
+	}
+
+	return Sub(MultiplyScalar(incident, eta), MultiplyScalar(normal, eta*normalDotIncident+math.Sqrt(k))), true
+}
+
 // Calculate the non-squared magnitude of a vector.
 //
 // This function calculates the Pythagorean Theorem but without squaring the result.
@@ -99,6 +140,11 @@ func (v *Vec3) MagnitudeSqrt() float64 {
 	return math.Sqrt(v.Magnitude())
 }
 
+// Calculate the length of a vector
+func Length(v Vec3) float64 {
+	return v.MagnitudeSqrt()
+}
+
 // Normalize a vector
 func Normalize(v Vec3) Vec3 {
 	magnitude := v.MagnitudeSqrt()
@@ -1,6 +1,9 @@
 package mathematics
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 const epsilon = 0.0001
 
@@ -83,6 +86,16 @@ func TestMultiply(t *testing.T) {
 	}
 }
 
+func TestDivide(t *testing.T) {
+	a := Vec3{10.0, 20.0, 30.0}
+	b := Vec3{2.0, 4.0, 5.0}
+	c := Divide(a, b)
+
+	if c.X != 5.0 || c.Y != 5.0 || c.Z != 6.0 {
+		t.Fatalf("Division failure: %v / %v != %v", a, b, c)
+	}
+}
+
 func TestDot(t *testing.T) {
 	a := Vec3{-6.0, 8.0, 0.0}
 	b := Vec3{5.0, 12.0, 0.0}
@@ -93,6 +106,16 @@ func TestDot(t *testing.T) {
 	}
 }
 
+func TestCross(t *testing.T) {
+	a := Vec3{1.0, 0.0, 0.0}
+	b := Vec3{0.0, 1.0, 0.0}
+	result := Cross(a, b)
+
+	if result.X != 0.0 || result.Y != 0.0 || result.Z != 1.0 {
+		t.Fatalf("Cross product failure: %v x %v != %v", a, b, result)
+	}
+}
+
 func TestNegate(t *testing.T) {
 	a := Vec3{2.0, 3.0, 4.0}
 	result := Negate(a)
@@ -102,6 +125,35 @@ func TestNegate(t *testing.T) {
 	}
 }
 
+func TestAbs(t *testing.T) {
+	a := Vec3{-2.0, 3.0, -4.0}
+	result := Abs(a)
+
+	if result.X != 2.0 || result.Y != 3.0 || result.Z != 4.0 {
+		t.Fatalf("Abs failure: |%v| != %v", a, result)
+	}
+}
+
+func TestComponentMax(t *testing.T) {
+	a := Vec3{1.0, 5.0, -3.0}
+	b := Vec3{2.0, 4.0, -4.0}
+	result := ComponentMax(a, b)
+
+	if result.X != 2.0 || result.Y != 5.0 || result.Z != -3.0 {
+		t.Fatalf("ComponentMax failure: max(%v, %v) != %v", a, b, result)
+	}
+}
+
+func TestComponentMin(t *testing.T) {
+	a := Vec3{1.0, 5.0, -3.0}
+	b := Vec3{2.0, 4.0, -4.0}
+	result := ComponentMin(a, b)
+
+	if result.X != 1.0 || result.Y != 4.0 || result.Z != -4.0 {
+		t.Fatalf("ComponentMin failure: min(%v, %v) != %v", a, b, result)
+	}
+}
+
 func TestReflect(t *testing.T) {
 	incident := Vec3{1.0, -1.0, 0.0}
 	normal := Vec3{0.0, 1.0, 0.0}
@@ -112,6 +164,26 @@ func TestReflect(t *testing.T) {
 	}
 }
 
+func TestRefract(t *testing.T) {
+	normal := Vec3{0.0, 1.0, 0.0}
+
+	straight, ok := Refract(Vec3{0.0, -1.0, 0.0}, normal, 1.0/1.5)
+	if !ok || straight != (Vec3{0.0, -1.0, 0.0}) {
+		t.Fatalf("Refract failure: a head-on vector should pass straight through but got %v", straight)
+	}
+
+	// Snell's law: sin(theta1) * n1 = sin(theta2) * n2
+	incident := Normalize(Vec3{1.0, -1.0, 0.0})
+	refraction, ok := Refract(incident, normal, 1.0/1.5)
+	if !ok || math.Abs(refraction.X-incident.X/1.5) > epsilon || math.Abs(refraction.MagnitudeSqrt()-1.0) > epsilon {
+		t.Fatalf("Refract failure: %v refracted against %v != %v", incident, normal, refraction)
+	}
+
+	if _, ok := Refract(incident, normal, 1.5); ok {
+		t.Fatalf("Refract failure: expected total internal reflection")
+	}
+}
+
 func TestMagnitude(t *testing.T) {
 	a := Vec3{3.0, 4.0, 0.0}
 	magnitude := a.Magnitude()
@@ -130,6 +202,15 @@ func TestMagnitudeSqrt(t *testing.T) {
 	}
 }
 
+func TestLength(t *testing.T) {
+	a := Vec3{3.0, 4.0, 0.0}
+	length := Length(a)
+
+	if length != 5.0 {
+		t.Fatalf("Length failure: expected 5.0 but got %f", length)
+	}
+}
+
 func TestNormalize(t *testing.T) {
 	a := Vec3{3.0, 4.0, 5.0}
 	normal := Normalize(a)
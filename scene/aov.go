@@ -0,0 +1,228 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+	. "github.com/tntmeijs/gengo/utility"
+)
+
+// Arbitrary output variable, an additional image that describes the surface the primary ray of each pixel hit
+type Aov int
+
+const (
+	// Distance travelled along the ray, which equals the linear depth for a normalized ray direction
+	AovDepth Aov = iota
+
+	// World space normal of the surface
+	AovNormal
+
+	// World space position of the surface
+	AovPosition
+
+	// Identifier of the material of the surface plus one, zero is reserved for rays that did not hit a surface
+	AovMaterialID
+
+	// Number of iterations the ray march needed before it came to a halt
+	AovStepCount
+
+	// Ambient occlusion of the surface, 1.0 means not occluded at all
+	AovAmbientOcclusion
+
+	// 1.0 when the ray hit a surface and 0.0 otherwise
+	AovHitMask
+)
+
+// Name of the AOV, used for file names and OpenEXR layer names
+func (a Aov) String() string {
+	switch a {
+	case AovDepth:
+		return "depth"
+	case AovNormal:
+		return "normal"
+	case AovPosition:
+		return "position"
+	case AovMaterialID:
+		return "material_id"
+	case AovStepCount:
+		return "step_count"
+	case AovAmbientOcclusion:
+		return "ambient_occlusion"
+	case AovHitMask:
+		return "hit_mask"
+	}
+
+	return "unknown"
+}
+
+// Names and data type of the OpenEXR channels the AOV is stored in
+func (a Aov) exrChannels() ([]string, ExrPixelType) {
+	switch a {
+	case AovDepth:
+		return []string{"Z"}, ExrPixelFloat
+	case AovNormal:
+		return []string{"X", "Y", "Z"}, ExrPixelHalf
+	case AovPosition:
+		return []string{"X", "Y", "Z"}, ExrPixelFloat
+	case AovMaterialID:
+		return []string{"id"}, ExrPixelUint
+	case AovStepCount:
+		return []string{"count"}, ExrPixelUint
+	}
+
+	return []string{"Y"}, ExrPixelHalf
+}
+
+// Calculate the value of the AOV for a single pixel. Surface properties are transparent and zero when the ray did
+// not hit a surface, material IDs are offset by one to keep material zero apart from the background in formats
+// that do not store alpha.
+func (a Aov) evaluate(didHit bool, hitInfo SurfaceHitInfo, occlusion float64) (Vec3, float64) {
+	coverage := 0.0
+	if didHit {
+		coverage = 1.0
+	}
+
+	switch a {
+	case AovDepth:
+		depth := hitInfo.RayLength * coverage
+		return Vec3{X: depth, Y: depth, Z: depth}, coverage
+	case AovNormal:
+		return MultiplyScalar(hitInfo.Normal, coverage), coverage
+	case AovPosition:
+		return MultiplyScalar(hitInfo.Point, coverage), coverage
+	case AovMaterialID:
+		id := float64(hitInfo.MaterialID+1) * coverage
+		return Vec3{X: id, Y: id, Z: id}, coverage
+	case AovStepCount:
+		steps := float64(hitInfo.StepCount)
+		return Vec3{X: steps, Y: steps, Z: steps}, 1.0
+	case AovAmbientOcclusion:
+		return Vec3{X: occlusion, Y: occlusion, Z: occlusion}, 1.0
+	}
+
+	return Vec3{X: coverage, Y: coverage, Z: coverage}, 1.0
+}
+
+// Ranges used to remap AOVs into [0.0, 1.0] when they are stored in low dynamic range formats
+type AovRanges struct {
+	MaxDepth     float64
+	MaxPosition  float64
+	MaxStepCount float64
+}
+
+// Create new AOV ranges with the following properties:
+//
+//	MaxDepth     : depth that maps to white
+//	MaxPosition  : positions in [-MaxPosition, MaxPosition] map to [0.0, 1.0] per axis
+//	MaxStepCount : step count that maps to white
+func NewAovRanges(maxDepth float64, maxPosition float64, maxStepCount float64) AovRanges {
+	return AovRanges{maxDepth, maxPosition, maxStepCount}
+}
+
+// Remap the values of an AOV buffer into [0.0, 1.0] so it can be viewed as a regular image. Normals are mapped
+// from [-1.0, 1.0] and every material ID gets its own color.
+func (a Aov) Visualize(buffer *Framebuffer, ranges AovRanges) Framebuffer {
+	result := NewFramebuffer(buffer.GetWidth(), buffer.GetHeight())
+
+	for y := 0; y < buffer.GetHeight(); y++ {
+		for x := 0; x < buffer.GetWidth(); x++ {
+			value := buffer.GetPixel(x, y)
+
+			switch a {
+			case AovDepth:
+				value = MultiplyScalar(value, 1.0/ranges.MaxDepth)
+			case AovNormal:
+				value = Add(MultiplyScalar(value, 0.5), Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+			case AovPosition:
+				value = Add(MultiplyScalar(value, 0.5/ranges.MaxPosition), Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+			case AovMaterialID:
+				value = materialIDColor(value.X - 1.0)
+			case AovStepCount:
+				value = MultiplyScalar(value, 1.0/ranges.MaxStepCount)
+			}
+
+			result.SetPixel(x, y, value, buffer.GetAlpha(x, y))
+		}
+	}
+
+	return result
+}
+
+// Pick a distinct color for a material ID by stepping around the hue circle with the golden ratio
+//
+// Reference: https://iquilezles.org/articles/palettes/
+func materialIDColor(id float64) Vec3 {
+	hue := math.Mod(id*0.618034, 1.0)
+	channel := func(offset float64) float64 {
+		return 0.5 + 0.5*math.Cos(2.0*math.Pi*(hue+offset))
+	}
+
+	return Vec3{X: channel(0.0), Y: channel(1.0 / 3.0), Z: channel(2.0 / 3.0)}
+}
+
+// Set of AOV buffers that are filled in alongside the color of each pixel
+type AovBuffers struct {
+	aovs    []Aov
+	buffers []Framebuffer
+}
+
+// Create a buffer for each of the specified AOVs
+func NewAovBuffers(width int, height int, aovs ...Aov) AovBuffers {
+	buffers := make([]Framebuffer, len(aovs))
+	for i := range buffers {
+		buffers[i] = NewFramebuffer(width, height)
+	}
+
+	return AovBuffers{aovs, buffers}
+}
+
+// Get the AOVs that are stored
+func (a *AovBuffers) GetAovs() []Aov {
+	return a.aovs
+}
+
+// Check whether any AOVs are stored at all
+func (a *AovBuffers) IsEmpty() bool {
+	return len(a.aovs) == 0
+}
+
+// Get the buffer of an AOV
+func (a *AovBuffers) GetBuffer(aov Aov) (*Framebuffer, bool) {
+	for i := range a.aovs {
+		if a.aovs[i] == aov {
+			return &a.buffers[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// Store the values of all AOVs for a single pixel
+func (a *AovBuffers) Record(x int, y int, didHit bool, hitInfo SurfaceHitInfo, occlusion float64) {
+	for i, aov := range a.aovs {
+		value, alpha := aov.evaluate(didHit, hitInfo, occlusion)
+		a.buffers[i].SetPixel(x, y, value, alpha)
+	}
+}
+
+// Copy the AOVs another set of buffers has in common with these buffers, the other buffers are placed at an offset
+func (a *AovBuffers) CopyFrom(other *AovBuffers, offsetX int, offsetY int) {
+	for i, aov := range a.aovs {
+		if buffer, exists := other.GetBuffer(aov); exists {
+			a.buffers[i].CopyFrom(buffer, offsetX, offsetY)
+		}
+	}
+}
+
+// Add every AOV as a layer of channels to an OpenEXR image
+func (a *AovBuffers) AddToExrImage(image *ExrImage) error {
+	for i, aov := range a.aovs {
+		channelNames, pixelType := aov.exrChannels()
+
+		if error := image.AddFramebuffer(aov.String(), &a.buffers[i], pixelType, channelNames...); error != nil {
+			return error
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+	. "github.com/tntmeijs/gengo/utility"
+)
+
+var testHitInfo = SurfaceHitInfo{
+	Point:      Vec3{X: 1.0, Y: -2.0, Z: 3.0},
+	Normal:     Vec3{Y: 1.0},
+	RayLength:  4.5,
+	StepCount:  12,
+	MaterialID: 3,
+}
+
+func TestAovBuffersRecord(t *testing.T) {
+	buffers := NewAovBuffers(2, 1, AovDepth, AovNormal, AovMaterialID, AovStepCount, AovAmbientOcclusion, AovHitMask)
+	buffers.Record(0, 0, true, testHitInfo, 0.25)
+	buffers.Record(1, 0, false, SurfaceHitInfo{RayLength: 25.0, StepCount: 7}, 1.0)
+
+	expected := map[Aov][2]Vec3{
+		AovDepth:            {{X: 4.5, Y: 4.5, Z: 4.5}, {}},
+		AovNormal:           {{Y: 1.0}, {}},
+		AovMaterialID:       {{X: 4.0, Y: 4.0, Z: 4.0}, {}},
+		AovStepCount:        {{X: 12.0, Y: 12.0, Z: 12.0}, {X: 7.0, Y: 7.0, Z: 7.0}},
+		AovAmbientOcclusion: {{X: 0.25, Y: 0.25, Z: 0.25}, {X: 1.0, Y: 1.0, Z: 1.0}},
+		AovHitMask:          {{X: 1.0, Y: 1.0, Z: 1.0}, {}},
+	}
+
+	for aov, values := range expected {
+		buffer, exists := buffers.GetBuffer(aov)
+		if !exists {
+			t.Fatalf("AOV failure: expected a buffer for %s", aov)
+		}
+
+		for x, value := range values {
+			if pixel := buffer.GetPixel(x, 0); pixel != value {
+				t.Fatalf("AOV failure: expected %v for %s at %d but got %v", value, aov, x, pixel)
+			}
+		}
+	}
+
+	// Surface properties are transparent where the ray missed
+	for _, aov := range []Aov{AovDepth, AovNormal, AovMaterialID} {
+		buffer, _ := buffers.GetBuffer(aov)
+		if buffer.GetAlpha(0, 0) != 1.0 || buffer.GetAlpha(1, 0) != 0.0 {
+			t.Fatalf("AOV failure: expected the coverage of %s to follow the hit mask", aov)
+		}
+	}
+
+	if _, exists := buffers.GetBuffer(AovPosition); exists {
+		t.Fatalf("AOV failure: expected no buffer for an AOV that was not requested")
+	}
+}
+
+func TestAovBuffersCopyFrom(t *testing.T) {
+	tile := NewAovBuffers(1, 1, AovDepth, AovPosition)
+	tile.Record(0, 0, true, testHitInfo, 1.0)
+
+	buffers := NewAovBuffers(2, 2, AovDepth, AovHitMask)
+	buffers.CopyFrom(&tile, 1, 1)
+
+	depth, _ := buffers.GetBuffer(AovDepth)
+	if pixel := depth.GetPixel(1, 1); pixel.X != 4.5 {
+		t.Fatalf("AOV failure: expected the depth to be copied to the offset but got %v", pixel)
+	}
+}
+
+func TestAovMaterialZero(t *testing.T) {
+	// Material zero has to stay apart from the background, even in formats that drop the alpha channel
+	hit, _ := AovMaterialID.evaluate(true, SurfaceHitInfo{MaterialID: 0}, 1.0)
+	miss, _ := AovMaterialID.evaluate(false, SurfaceHitInfo{}, 1.0)
+
+	if hit == miss {
+		t.Fatalf("AOV failure: expected material zero (%v) to differ from the background (%v)", hit, miss)
+	}
+}
+
+func TestAovVisualize(t *testing.T) {
+	buffers := NewAovBuffers(1, 1, AovDepth, AovNormal, AovPosition)
+	buffers.Record(0, 0, true, testHitInfo, 1.0)
+	ranges := NewAovRanges(9.0, 4.0, 100.0)
+
+	expected := map[Aov]Vec3{
+		AovDepth:    {X: 0.5, Y: 0.5, Z: 0.5},
+		AovNormal:   {X: 0.5, Y: 1.0, Z: 0.5},
+		AovPosition: {X: 0.625, Y: 0.25, Z: 0.875},
+	}
+
+	for aov, value := range expected {
+		buffer, _ := buffers.GetBuffer(aov)
+		visualization := aov.Visualize(buffer, ranges)
+
+		if pixel := visualization.GetPixel(0, 0); Length(Sub(pixel, value)) > tolerance {
+			t.Fatalf("AOV failure: expected %v for %s but got %v", value, aov, pixel)
+		}
+	}
+
+	// Neighbouring material IDs get clearly different colors
+	first, second := materialIDColor(1.0), materialIDColor(2.0)
+	if Length(Sub(first, second)) < 0.25 || math.Max(first.X, math.Max(first.Y, first.Z)) > 1.0 {
+		t.Fatalf("AOV failure: expected distinct material colors but got %v and %v", first, second)
+	}
+}
+
+func TestAovBuffersAddToExrImage(t *testing.T) {
+	buffers := NewAovBuffers(1, 1, AovDepth, AovNormal, AovMaterialID)
+	buffers.Record(0, 0, true, testHitInfo, 1.0)
+
+	image := NewExrImage(1, 1)
+	if error := buffers.AddToExrImage(&image); error != nil {
+		t.Fatalf("AOV failure: %s", error.Error())
+	}
+
+	expected := map[string]ExrPixelType{"depth.Z": ExrPixelFloat, "normal.Y": ExrPixelHalf, "material_id.id": ExrPixelUint}
+	for name, pixelType := range expected {
+		channel, exists := image.GetChannel(name)
+		if !exists || channel.PixelType != pixelType {
+			t.Fatalf("AOV failure: expected channel %s in %v", name, image.GetChannelNames())
+		}
+	}
+}
@@ -26,6 +26,11 @@ func (c *Camera) GetDirection() Vec3 {
 	return c.direction
 }
 
+// Get the distance beyond which the camera no longer sees anything
+func (c *Camera) GetFarPlane() float64 {
+	return c.farPlane
+}
+
 // Set the point in space where the camera is pointed towards
 func (c *Camera) SetFocusPoint(focus Vec3) {
 	c.direction = Normalize(Sub(focus, c.Position))
@@ -64,17 +69,32 @@ func (c *Camera) GenerateRayForPixelWithOffset(pixelX int, pixelY int, offsetX f
 }
 
 // Cast a ray into the scene and march towards the surface until an intersection is found, or until the
-// ray passes the far plane of the camera
+// ray passes the far plane of the camera. The direction is normalized, so the step size and the ray length are in
+// world units just like they are when sphere tracing.
 func (c *Camera) MarchAlongRay(ray Ray, scene Scene, stepSize float64) (bool, SurfaceHitInfo) {
+	direction := Normalize(ray.Direction)
+	stepCount := 0
+	pointInSpace := ray.Origin
+
 	for distance := 0.0; distance < c.farPlane; distance += stepSize {
-		pointInSpace := Add(ray.Origin, MultiplyScalar(ray.Direction, distance))
+		pointInSpace = Add(ray.Origin, MultiplyScalar(direction, distance))
+		stepCount++
 
 		if scene.DoesPointIntersectSurface(pointInSpace) {
 			// Surface intersection found
-			return true, scene.GetIntersectionPointSurfaceHitInfo(pointInSpace, distance)
+			hitInfo := scene.GetIntersectionPointSurfaceHitInfo(pointInSpace, distance)
+			hitInfo.TerminationDistance = scene.GetDistance(pointInSpace)
+			hitInfo.StepCount = stepCount
+			return true, hitInfo
 		}
 	}
 
 	// No surface intersection found
-	return false, SurfaceHitInfo{}
+	return false, SurfaceHitInfo{RayLength: c.farPlane, TerminationDistance: scene.GetDistance(pointInSpace), StepCount: stepCount, Termination: TerminationMaxDistance}
+}
+
+// Cast a ray into the scene and sphere trace towards the surface until an intersection is found, until the
+// ray passes the far plane of the camera, or until the maximum number of iterations has been reached
+func (c *Camera) SphereTraceAlongRay(ray Ray, scene Scene, settings SphereTraceSettings) (bool, SurfaceHitInfo) {
+	return scene.SphereTrace(ray, c.farPlane, settings)
 }
@@ -0,0 +1,22 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+func TestMarchAlongRayMatchesSphereTrace(t *testing.T) {
+	camera := NewCamera(Vec3{}, Vec3{Z: 1.0}, 0.0, 10.0)
+	scene := NewScene(Translate(func(point Vec3) float64 { return SphereSDF(point, 1.0) }, Vec3{Z: 5.0}))
+
+	// Off-center rays are not normalized, both strategies should still report the ray length in world units
+	ray := Ray{Direction: Vec3{Z: 2.0}}
+	_, marched := camera.MarchAlongRay(ray, scene, 0.01)
+	_, traced := camera.SphereTraceAlongRay(ray, scene, NewSphereTraceSettings(1.0, 0.001, 64))
+
+	if math.Abs(marched.RayLength-4.0) > 0.01 || math.Abs(traced.RayLength-4.0) > 0.01 {
+		t.Fatalf("MarchAlongRay failure: expected a ray length of 4.0 but got %f when marching and %f when sphere tracing", marched.RayLength, traced.RayLength)
+	}
+}
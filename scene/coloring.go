@@ -0,0 +1,74 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+	. "github.com/tntmeijs/gengo/utility"
+)
+
+// Value of an orbit trap that is used to color a surface
+type OrbitTrapChannel int
+
+const (
+	// Closest distance between the orbit and the trap point
+	ChannelPointDistance OrbitTrapChannel = iota
+
+	// Closest distance between the orbit and the trap plane
+	ChannelPlaneDistance
+
+	// Closest distance between the orbit and the trap axis
+	ChannelAxisDistance
+
+	// Number of iterations before the orbit escaped
+	ChannelIterationCount
+
+	// Fractional number of iterations before the orbit escaped
+	ChannelSmoothIteration
+)
+
+// Maps orbit trap data through a gradient to get the albedo of a fractal surface
+type OrbitTrapColoring struct {
+	channel       OrbitTrapChannel
+	gradient      Gradient
+	scale, offset float64
+	repeat        bool
+}
+
+// Create a new orbit trap coloring with the following properties:
+//
+//	Channel  : orbit trap value used to sample the gradient
+//	Gradient : gradient that maps the value onto a color
+//	Scale    : value the channel is multiplied with before sampling the gradient
+//	Offset   : value added to the channel after scaling it
+//	Repeat   : wrap the gradient around instead of clamping it to its end points
+func NewOrbitTrapColoring(channel OrbitTrapChannel, gradient Gradient, scale float64, offset float64, repeat bool) OrbitTrapColoring {
+	return OrbitTrapColoring{channel, gradient, scale, offset, repeat}
+}
+
+// Get the value of the selected channel from the orbit trap
+func (c *OrbitTrapColoring) channelValue(trap OrbitTrap) float64 {
+	switch c.channel {
+	case ChannelPlaneDistance:
+		return trap.MinPlaneDistance
+	case ChannelAxisDistance:
+		return trap.MinAxisDistance
+	case ChannelIterationCount:
+		return float64(trap.Iterations)
+	case ChannelSmoothIteration:
+		return trap.SmoothIteration
+	default:
+		return trap.MinPointDistance
+	}
+}
+
+// Calculate the albedo of a surface from its orbit trap data
+func (c *OrbitTrapColoring) Albedo(trap OrbitTrap) Vec3 {
+	position := c.channelValue(trap)*c.scale + c.offset
+
+	if c.repeat {
+		position -= math.Floor(position)
+	}
+
+	return c.gradient.Sample(position)
+}
@@ -0,0 +1,144 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+	. "github.com/tntmeijs/gengo/utility"
+)
+
+// False color visualization that replaces the shading of the scene
+type DebugMode int
+
+const (
+	// Regular shading, no visualization
+	DebugModeNone DebugMode = iota
+
+	// World space normals mapped from [-1.0, 1.0] to RGB
+	DebugModeNormals
+
+	// Heatmap of the distance to the surface, rays that missed are black
+	DebugModeDepth
+
+	// Heatmap of the number of iterations the ray march needed
+	DebugModeStepCount
+
+	// Heatmap of the SDF value where the ray march stopped, on a logarithmic scale relative to the minimum hit
+	// distance
+	DebugModeTerminationDistance
+
+	// Contour lines of the SDF on a slicing plane, the surface behind the plane is shown in gray
+	DebugModeIsolines
+
+	// Hits are white, rays that travelled beyond the maximum distance are black, and rays that ran out of
+	// iterations are red
+	DebugModeHitMask
+)
+
+// Settings that control the debug visualizations
+type DebugSettings struct {
+	Mode           DebugMode
+	MaxDepth       float64
+	MaxStepCount   int
+	MinHitDistance float64
+	SlicePoint     Vec3
+	SliceNormal    Vec3
+	IsolineSpacing float64
+}
+
+// Create new debug settings with the following properties:
+//
+//	Mode           : visualization that replaces the shading
+//	MaxDepth       : depth at the hot end of the depth heatmap
+//	MaxStepCount   : step count at the hot end of the step count heatmap
+//	MinHitDistance : distance below which the ray march considers the surface to be hit
+//	SlicePoint     : point on the slicing plane of the isolines
+//	SliceNormal    : normal of the slicing plane of the isolines
+//	IsolineSpacing : distance between two consecutive isolines
+func NewDebugSettings(mode DebugMode, maxDepth float64, maxStepCount int, minHitDistance float64, slicePoint Vec3, sliceNormal Vec3, isolineSpacing float64) DebugSettings {
+	return DebugSettings{mode, maxDepth, maxStepCount, minHitDistance, slicePoint, Normalize(sliceNormal), isolineSpacing}
+}
+
+// Heatmap that runs from cold to hot
+var debugHeatmap = NewGradient(
+	NewColorStop(0.0, Color{Red: 48, Green: 18, Blue: 59, Alpha: 255}),
+	NewColorStop(0.25, Color{Red: 40, Green: 150, Blue: 240, Alpha: 255}),
+	NewColorStop(0.5, Color{Red: 60, Green: 230, Blue: 110, Alpha: 255}),
+	NewColorStop(0.75, Color{Red: 250, Green: 190, Blue: 40, Alpha: 255}),
+	NewColorStop(1.0, Color{Red: 180, Green: 20, Blue: 10, Alpha: 255}),
+)
+
+// Calculate the debug color of a pixel from the ray march of its primary ray
+func (s *Scene) DebugColor(ray Ray, didHit bool, hitInfo SurfaceHitInfo, settings DebugSettings) Vec3 {
+	switch settings.Mode {
+	case DebugModeNormals:
+		if !didHit {
+			return Vec3{}
+		}
+
+		return Add(MultiplyScalar(hitInfo.Normal, 0.5), Vec3{X: 0.5, Y: 0.5, Z: 0.5})
+	case DebugModeDepth:
+		if !didHit {
+			return Vec3{}
+		}
+
+		return debugHeatmap.Sample(hitInfo.RayLength / settings.MaxDepth)
+	case DebugModeStepCount:
+		return debugHeatmap.Sample(float64(hitInfo.StepCount) / float64(settings.MaxStepCount))
+	case DebugModeTerminationDistance:
+		// Every color covers a factor of ten, distances below the minimum hit distance are the coldest color
+		relativeDistance := math.Max(hitInfo.TerminationDistance/settings.MinHitDistance, 1.0)
+		return debugHeatmap.Sample(math.Log10(relativeDistance) / 4.0)
+	case DebugModeIsolines:
+		return s.isolineColor(ray, didHit, hitInfo, settings)
+	case DebugModeHitMask:
+		switch {
+		case didHit:
+			return Vec3{X: 1.0, Y: 1.0, Z: 1.0}
+		case hitInfo.Termination == TerminationMaxIterations:
+			return Vec3{X: 1.0}
+		}
+
+		return Vec3{}
+	}
+
+	return Vec3{}
+}
+
+// Color the slicing plane by the SDF: orange outside of shapes, blue inside of shapes, with darker bands every
+// isoline spacing and a white line where the surface crosses the plane
+//
+// Reference: https://iquilezles.org/articles/distfunctions2d/
+func (s *Scene) isolineColor(ray Ray, didHit bool, hitInfo SurfaceHitInfo, settings DebugSettings) Vec3 {
+	direction := Normalize(ray.Direction)
+	denominator := Dot(direction, settings.SliceNormal)
+	planeDistance := math.Inf(1)
+
+	if math.Abs(denominator) > epsilon {
+		planeDistance = Dot(Sub(settings.SlicePoint, ray.Origin), settings.SliceNormal) / denominator
+	}
+
+	// The surface is in front of the slicing plane, or the plane is not visible at all
+	if math.IsInf(planeDistance, 1) || planeDistance < 0.0 || (didHit && hitInfo.RayLength < planeDistance) {
+		if !didHit {
+			return Vec3{}
+		}
+
+		gray := 0.1 + 0.4*math.Max(Dot(hitInfo.Normal, Negate(direction)), 0.0)
+		return Vec3{X: gray, Y: gray, Z: gray}
+	}
+
+	distance := s.sceneSDF(Add(ray.Origin, MultiplyScalar(direction, planeDistance)))
+
+	color := Vec3{X: 0.9, Y: 0.6, Z: 0.3}
+	if distance < 0.0 {
+		color = Vec3{X: 0.65, Y: 0.85, Z: 1.0}
+	}
+
+	color = MultiplyScalar(color, 1.0-math.Exp(-6.0*math.Abs(distance)))
+	color = MultiplyScalar(color, 0.8+0.2*math.Cos(2.0*math.Pi*distance/settings.IsolineSpacing))
+
+	// Highlight the zero isoline, which is where the surface intersects the plane
+	line := 1.0 - ClampBetween(math.Abs(distance)/(0.1*settings.IsolineSpacing), 0.0, 1.0)
+	return mixVec3(color, Vec3{X: 1.0, Y: 1.0, Z: 1.0}, line)
+}
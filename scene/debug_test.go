@@ -0,0 +1,95 @@
+package scene
+
+import (
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+func debugTestSettings(mode DebugMode) DebugSettings {
+	return NewDebugSettings(mode, 10.0, 100, 0.001, Vec3{}, Vec3{Z: -1.0}, 0.25)
+}
+
+func TestDebugColorNormals(t *testing.T) {
+	scene := NewScene(func(point Vec3) float64 { return SphereSDF(point, 1.0) })
+	ray := Ray{Origin: Vec3{Z: -5.0}, Direction: Vec3{Z: 1.0}}
+	didHit, hitInfo := scene.SphereTrace(ray, 10.0, testTraceSettings)
+
+	if color := scene.DebugColor(ray, didHit, hitInfo, debugTestSettings(DebugModeNormals)); !nearlyEqual(color, Vec3{X: 0.5, Y: 0.5, Z: 0.0}) {
+		t.Fatalf("Debug failure: expected the normal facing the camera to be {0.5 0.5 0} but got %v", color)
+	}
+
+	if color := scene.DebugColor(ray, false, SurfaceHitInfo{}, debugTestSettings(DebugModeDepth)); color != (Vec3{}) {
+		t.Fatalf("Debug failure: expected a missed ray to have no depth but got %v", color)
+	}
+}
+
+func TestDebugColorHitMask(t *testing.T) {
+	scene := emptyScene()
+	settings := debugTestSettings(DebugModeHitMask)
+
+	cases := []struct {
+		didHit      bool
+		termination MarchTermination
+		expected    Vec3
+	}{
+		{true, TerminationSurfaceHit, Vec3{X: 1.0, Y: 1.0, Z: 1.0}},
+		{false, TerminationMaxDistance, Vec3{}},
+		{false, TerminationMaxIterations, Vec3{X: 1.0}},
+	}
+
+	for _, c := range cases {
+		if color := scene.DebugColor(Ray{}, c.didHit, SurfaceHitInfo{Termination: c.termination}, settings); color != c.expected {
+			t.Fatalf("Debug failure: expected %v for termination %d but got %v", c.expected, c.termination, color)
+		}
+	}
+}
+
+func TestDebugColorTerminationDistance(t *testing.T) {
+	scene := emptyScene()
+	settings := debugTestSettings(DebugModeTerminationDistance)
+
+	near := scene.DebugColor(Ray{}, true, SurfaceHitInfo{TerminationDistance: 0.0005}, settings)
+	far := scene.DebugColor(Ray{}, false, SurfaceHitInfo{TerminationDistance: 100.0}, settings)
+
+	if near != debugHeatmap.Sample(0.0) || far != debugHeatmap.Sample(1.0) {
+		t.Fatalf("Debug failure: expected the ends of the heatmap but got %v and %v", near, far)
+	}
+}
+
+func TestSphereTraceTerminationDistance(t *testing.T) {
+	scene := NewScene(func(point Vec3) float64 { return SphereSDF(point, 1.0) })
+	didHit, hitInfo := scene.SphereTrace(Ray{Origin: Vec3{Z: -5.0}, Direction: Vec3{Z: 1.0}}, 10.0, testTraceSettings)
+
+	if !didHit || hitInfo.TerminationDistance >= testTraceSettings.MinHitDistance {
+		t.Fatalf("Debug failure: expected the hit to stop within the minimum hit distance but got %f", hitInfo.TerminationDistance)
+	}
+
+	didHit, hitInfo = scene.SphereTrace(Ray{Origin: Vec3{Y: 3.0, Z: -5.0}, Direction: Vec3{Z: 1.0}}, 10.0, testTraceSettings)
+	if didHit || hitInfo.TerminationDistance < 1.0 {
+		t.Fatalf("Debug failure: expected a miss to stop far away from the surface but got %f", hitInfo.TerminationDistance)
+	}
+}
+
+func TestDebugColorIsolines(t *testing.T) {
+	scene := NewScene(func(point Vec3) float64 { return SphereSDF(point, 1.0) })
+	settings := debugTestSettings(DebugModeIsolines)
+
+	// The slicing plane through the center of the sphere is closer than the back of the sphere
+	surface := scene.DebugColor(Ray{Origin: Vec3{X: 1.0, Z: -5.0}, Direction: Vec3{Z: 1.0}}, false, SurfaceHitInfo{}, settings)
+	if !nearlyEqual(surface, Vec3{X: 1.0, Y: 1.0, Z: 1.0}) {
+		t.Fatalf("Debug failure: expected the zero isoline to be white but got %v", surface)
+	}
+
+	inside := scene.DebugColor(Ray{Origin: Vec3{X: 0.5, Z: -5.0}, Direction: Vec3{Z: 1.0}}, false, SurfaceHitInfo{}, settings)
+	outside := scene.DebugColor(Ray{Origin: Vec3{X: 1.5, Z: -5.0}, Direction: Vec3{Z: 1.0}}, false, SurfaceHitInfo{}, settings)
+	if inside.Z <= inside.X || outside.X <= outside.Z {
+		t.Fatalf("Debug failure: expected blue inside and orange outside but got %v and %v", inside, outside)
+	}
+
+	// A surface in front of the plane hides the plane
+	hidden := scene.DebugColor(Ray{Origin: Vec3{Z: -5.0}, Direction: Vec3{Z: 1.0}}, true, SurfaceHitInfo{RayLength: 4.0, Normal: Vec3{Z: -1.0}}, settings)
+	if !nearlyEqual(hidden, Vec3{X: 0.5, Y: 0.5, Z: 0.5}) {
+		t.Fatalf("Debug failure: expected the surface in front of the plane to be gray but got %v", hidden)
+	}
+}
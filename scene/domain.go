@@ -0,0 +1,109 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Wrap a coordinate into a single cell of a grid with the specified period, a period of zero disables repetition
+func repeatCoordinate(value float64, period float64, limit float64) float64 {
+	if period == 0.0 {
+		return value
+	}
+
+	return value - period*ClampBetween(math.Round(value/period), -limit, limit)
+}
+
+// Infinitely repeat a signed distance function along each axis. The shape is expected to fit within a single
+// cell of the grid, a period of zero along an axis disables repetition along that axis.
+//
+// Reference: https://iquilezles.org/articles/sdfrepetition/
+func Repeat(shape sdf, period Vec3) sdf {
+	return RepeatLimited(shape, period, Vec3{X: math.Inf(1), Y: math.Inf(1), Z: math.Inf(1)})
+}
+
+// Repeat a signed distance function along each axis a limited number of times. The limit is the number of
+// copies placed on either side of the original along each axis.
+func RepeatLimited(shape sdf, period Vec3, limit Vec3) sdf {
+	return func(point Vec3) float64 {
+		return shape(Vec3{
+			X: repeatCoordinate(point.X, period.X, limit.X),
+			Y: repeatCoordinate(point.Y, period.Y, limit.Y),
+			Z: repeatCoordinate(point.Z, period.Z, limit.Z),
+		})
+	}
+}
+
+// Repeat a signed distance function radially around the Y axis. The shape is copied into each of the sectors,
+// the first copy sits on the positive X axis. The count is kept at one or more, which leaves a single copy.
+func RepeatPolar(shape sdf, count int) sdf {
+	if count < 1 {
+		count = 1
+	}
+
+	sectorAngle := 2.0 * math.Pi / float64(count)
+
+	return func(point Vec3) float64 {
+		angle := math.Atan2(point.Z, point.X)
+		sector := math.Round(angle / sectorAngle)
+		sin, cos := math.Sincos(-sector * sectorAngle)
+
+		return shape(Vec3{X: cos*point.X - sin*point.Z, Y: point.Y, Z: sin*point.X + cos*point.Z})
+	}
+}
+
+// Twist a signed distance function around the Y axis, the strength is the rotation in radians per unit along
+// the Y axis.
+//
+// Twisting does not preserve distances, the result underestimates or overestimates the distance depending on
+// the strength, so consider lowering the sphere tracing relaxation when using strong twists.
+func Twist(shape sdf, strength float64) sdf {
+	return func(point Vec3) float64 {
+		sin, cos := math.Sincos(strength * point.Y)
+		return shape(Vec3{X: cos*point.X - sin*point.Z, Y: point.Y, Z: sin*point.X + cos*point.Z})
+	}
+}
+
+// Bend a signed distance function around the Z axis, the strength is the rotation in radians per unit along
+// the X axis.
+//
+// Like twisting, bending does not preserve distances.
+func Bend(shape sdf, strength float64) sdf {
+	return func(point Vec3) float64 {
+		sin, cos := math.Sincos(strength * point.X)
+		return shape(Vec3{X: cos*point.X - sin*point.Y, Y: sin*point.X + cos*point.Y, Z: point.Z})
+	}
+}
+
+// Stretch a signed distance function by inserting the specified half lengths along each axis at the origin,
+// the shape is split in half and the gap between the halves is filled with its cross-section
+func Elongate(shape sdf, halfLengths Vec3) sdf {
+	return func(point Vec3) float64 {
+		q := Sub(Abs(point), halfLengths)
+		return shape(ComponentMax(q, Vec3{})) + math.Min(math.Max(q.X, math.Max(q.Y, q.Z)), 0.0)
+	}
+}
+
+// Turn a signed distance function into a hollow shell of the specified thickness around its surface, onion
+// layers can be created by applying this multiple times
+func Onion(shape sdf, thickness float64) sdf {
+	return func(point Vec3) float64 {
+		return math.Abs(shape(point)) - thickness
+	}
+}
+
+// Round off a signed distance function by inflating its surface by the radius
+func Round(shape sdf, radius float64) sdf {
+	return func(point Vec3) float64 {
+		return shape(point) - radius
+	}
+}
+
+// Displace the surface of a signed distance function using a user-defined function. Unless the displacement
+// is small and smooth the result is no longer an exact distance.
+func Displace(shape sdf, displacement func(point Vec3) float64) sdf {
+	return func(point Vec3) float64 {
+		return shape(point) + displacement(point)
+	}
+}
@@ -0,0 +1,100 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+func TestRepeat(t *testing.T) {
+	verifyDistances(t, "Repeat", Repeat(unitSphere, Vec3{X: 4.0, Y: 0.0, Z: 4.0}), []distanceSample{
+		{Vec3{X: 8.0, Y: 0.0, Z: -4.0}, -1.0},
+		{Vec3{X: 2.0, Y: 0.0, Z: 0.0}, 1.0},
+		{Vec3{X: 0.0, Y: 4.0, Z: 0.0}, 3.0},
+	})
+}
+
+func TestRepeatLimited(t *testing.T) {
+	verifyDistances(t, "RepeatLimited", RepeatLimited(unitSphere, Vec3{X: 4.0, Y: 0.0, Z: 0.0}, Vec3{X: 1.0, Y: 0.0, Z: 0.0}), []distanceSample{
+		{Vec3{X: 4.0, Y: 0.0, Z: 0.0}, -1.0},
+		{Vec3{X: -4.0, Y: 0.0, Z: 0.0}, -1.0},
+		{Vec3{X: 8.0, Y: 0.0, Z: 0.0}, 3.0},
+	})
+}
+
+func TestRepeatPolar(t *testing.T) {
+	shifted := Translate(unitSphere, Vec3{X: 3.0, Y: 0.0, Z: 0.0})
+
+	verifyDistances(t, "RepeatPolar", RepeatPolar(shifted, 4), []distanceSample{
+		{Vec3{X: 3.0, Y: 0.0, Z: 0.0}, -1.0},
+		{Vec3{X: 0.0, Y: 0.0, Z: 3.0}, -1.0},
+		{Vec3{X: -3.0, Y: 0.0, Z: 0.0}, -1.0},
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, 2.0},
+	})
+}
+
+func TestRepeatPolarInvalidCount(t *testing.T) {
+	shifted := Translate(unitSphere, Vec3{X: 3.0, Y: 0.0, Z: 0.0})
+
+	// Counts below one leave the original shape as the only copy
+	for _, count := range []int{0, -4} {
+		verifyDistances(t, "RepeatPolar", RepeatPolar(shifted, count), []distanceSample{
+			{Vec3{X: 3.0, Y: 0.0, Z: 0.0}, -1.0},
+			{Vec3{X: -3.0, Y: 0.0, Z: 0.0}, 5.0},
+		})
+	}
+}
+
+func TestTwist(t *testing.T) {
+	box := func(point Vec3) float64 { return BoxSDF(point, Vec3{X: 2.0, Y: 10.0, Z: 0.5}) }
+
+	// A quarter turn at a height of one unit turns the long side of the box towards the Z axis
+	verifyDistances(t, "Twist", Twist(box, math.Pi/2.0), []distanceSample{
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, -0.5},
+		{Vec3{X: 1.5, Y: 0.0, Z: 0.0}, -0.5},
+		{Vec3{X: 0.0, Y: 1.0, Z: 1.5}, -0.5},
+	})
+}
+
+func TestBend(t *testing.T) {
+	bar := func(point Vec3) float64 { return BoxSDF(point, Vec3{X: 10.0, Y: 0.5, Z: 0.5}) }
+
+	// An eighth turn at half a unit along the X axis moves the center of the bar half a unit down
+	verifyDistances(t, "Bend", Bend(bar, math.Pi/2.0), []distanceSample{
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, -0.5},
+		{Vec3{X: 0.5, Y: -0.5, Z: 0.0}, -0.5},
+		{Vec3{X: 0.5, Y: 0.0, Z: 0.0}, -0.146447},
+	})
+}
+
+func TestElongate(t *testing.T) {
+	verifyDistances(t, "Elongate", Elongate(unitSphere, Vec3{X: 2.0, Y: 0.0, Z: 0.0}), []distanceSample{
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, -1.0},
+		{Vec3{X: 3.0, Y: 0.0, Z: 0.0}, 0.0},
+		{Vec3{X: 1.5, Y: 2.0, Z: 0.0}, 1.0},
+	})
+}
+
+func TestOnion(t *testing.T) {
+	verifyDistances(t, "Onion", Onion(unitSphere, 0.1), []distanceSample{
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, 0.9},
+		{Vec3{X: 1.0, Y: 0.0, Z: 0.0}, -0.1},
+		{Vec3{X: 2.0, Y: 0.0, Z: 0.0}, 0.9},
+	})
+}
+
+func TestRound(t *testing.T) {
+	verifyDistances(t, "Round", Round(unitSphere, 0.5), []distanceSample{
+		{Vec3{X: 2.0, Y: 0.0, Z: 0.0}, 0.5},
+	})
+}
+
+func TestDisplace(t *testing.T) {
+	displaced := Displace(unitSphere, func(point Vec3) float64 { return 0.25 * point.Y })
+
+	verifyDistances(t, "Displace", displaced, []distanceSample{
+		{Vec3{X: 2.0, Y: 0.0, Z: 0.0}, 1.0},
+		{Vec3{X: 0.0, Y: 2.0, Z: 0.0}, 1.5},
+	})
+}
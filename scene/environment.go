@@ -0,0 +1,206 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+	. "github.com/tntmeijs/gengo/utility"
+)
+
+// Light arriving from infinitely far away, seen by every ray that leaves the scene without hitting a surface
+type Environment interface {
+	// Linear color of the light arriving from the specified direction, the direction has to be normalized
+	Radiance(direction Vec3) Vec3
+}
+
+// Environment with the same color in every direction
+type SolidEnvironment struct {
+	Color Vec3
+}
+
+// Environment that changes color from the bottom of the sky to the top
+type GradientEnvironment struct {
+	gradient Gradient
+}
+
+// Analytic model of the color of a clear daytime sky
+//
+// Reference: https://courses.cs.duke.edu/cps124/spring08/assign/07_papers/p91-preetham.pdf
+type PreethamSky struct {
+	sunDirection                 Vec3
+	intensity                    float64
+	luminance, chromaX, chromaY  [5]float64
+	zenithChromaX, zenithChromaY float64
+	perezZenith                  [3]float64
+}
+
+// Environment looked up in an image using the equirectangular projection, where the horizontal axis of the image
+// maps onto the angle around the Y axis and the vertical axis maps onto the angle from the top of the sky
+type EnvironmentMap struct {
+	image     Framebuffer
+	intensity float64
+	rotation  float64
+}
+
+// Light source that lights the scene with the light arriving from the environment (image-based lighting)
+type EnvironmentLight struct {
+	LightProperties
+	Samples     int
+	environment Environment
+}
+
+// Create a new environment with the same color in every direction
+func NewSolidEnvironment(color Vec3) *SolidEnvironment {
+	return &SolidEnvironment{color}
+}
+
+// Create a new gradient environment, the gradient is sampled at 0.0 straight down and at 1.0 straight up
+func NewGradientEnvironment(gradient Gradient) *GradientEnvironment {
+	return &GradientEnvironment{gradient}
+}
+
+// Create a new sky with the following properties:
+//
+//	SunDirection : direction from the scene towards the sun, the sun is kept above the horizon
+//	Turbidity    : haziness of the atmosphere, ranges from about 2.0 (clear) to 10.0 (hazy)
+//	Intensity    : luminance of the sky straight up, the model is scaled to match it
+func NewPreethamSky(sunDirection Vec3, turbidity float64, intensity float64) *PreethamSky {
+	sunDirection = Normalize(Vec3{X: sunDirection.X, Y: math.Max(sunDirection.Y, 0.01), Z: sunDirection.Z})
+	t := ClampBetween(turbidity, 1.0, 20.0)
+	theta := math.Acos(sunDirection.Y)
+
+	sky := PreethamSky{
+		sunDirection: sunDirection,
+		intensity:    intensity,
+		luminance:    [5]float64{0.1787*t - 1.4630, -0.3554*t + 0.4275, -0.0227*t + 5.3251, 0.1206*t - 2.5771, -0.0670*t + 0.3703},
+		chromaX:      [5]float64{-0.0193*t - 0.2592, -0.0665*t + 0.0008, -0.0004*t + 0.2125, -0.0641*t - 0.8989, -0.0033*t + 0.0452},
+		chromaY:      [5]float64{-0.0167*t - 0.2608, -0.0950*t + 0.0092, -0.0079*t + 0.2102, -0.0441*t - 1.6537, -0.0109*t + 0.0529},
+	}
+
+	theta2 := theta * theta
+	theta3 := theta2 * theta
+	sky.zenithChromaX = t*t*(0.00166*theta3-0.00375*theta2+0.00209*theta) +
+		t*(-0.02903*theta3+0.06377*theta2-0.03202*theta+0.00394) +
+		(0.11693*theta3 - 0.21196*theta2 + 0.06052*theta + 0.25886)
+	sky.zenithChromaY = t*t*(0.00275*theta3-0.00610*theta2+0.00317*theta) +
+		t*(-0.04214*theta3+0.08970*theta2-0.04153*theta+0.00516) +
+		(0.15346*theta3 - 0.26756*theta2 + 0.06670*theta + 0.26688)
+
+	// Values of the distribution straight up, every direction is expressed relative to these
+	sky.perezZenith = [3]float64{perez(sky.luminance, 0.0, theta), perez(sky.chromaX, 0.0, theta), perez(sky.chromaY, 0.0, theta)}
+
+	return &sky
+}
+
+// Create a new environment map from an image, the intensity scales the colors in the image and the rotation
+// turns the environment around the Y axis in radians
+func NewEnvironmentMap(image Framebuffer, intensity float64, rotation float64) *EnvironmentMap {
+	return &EnvironmentMap{image, intensity, rotation}
+}
+
+// Load a new environment map from a Radiance .hdr file
+func LoadEnvironmentMap(fileName string, intensity float64, rotation float64) (*EnvironmentMap, error) {
+	image, error := ReadRadianceHdrFile(fileName)
+	if error != nil {
+		return nil, error
+	}
+
+	return NewEnvironmentMap(image, intensity, rotation), nil
+}
+
+// Create a new light that lights the scene using an environment, more samples result in smoother lighting at
+// the cost of performance
+func NewEnvironmentLight(environment Environment, intensity float64, samples int) *EnvironmentLight {
+	return &EnvironmentLight{LightProperties{Color: Vec3{X: 1.0, Y: 1.0, Z: 1.0}, Intensity: intensity}, samples, environment}
+}
+
+// Sample the environment in a direction picked uniformly on the sphere. The radiance is divided by the probability
+// of picking the direction, which means samples below the surface are simply wasted.
+func (l *EnvironmentLight) Sample(point Vec3, u float64, v float64) LightSample {
+	y := 1.0 - 2.0*u
+	radius := math.Sqrt(math.Max(1.0-y*y, 0.0))
+	sin, cos := math.Sincos(2.0 * math.Pi * v)
+	direction := Vec3{X: radius * cos, Y: y, Z: radius * sin}
+
+	radiance := Multiply(l.environment.Radiance(direction), l.radiance())
+	return LightSample{direction, math.Inf(1), MultiplyScalar(radiance, 4.0*math.Pi)}
+}
+
+// Number of directions in which the environment is sampled
+func (l *EnvironmentLight) SampleCount() int {
+	return l.Samples
+}
+
+// Get the color of the environment
+func (e *SolidEnvironment) Radiance(direction Vec3) Vec3 {
+	return e.Color
+}
+
+// Sample the gradient based on the height of the direction
+func (e *GradientEnvironment) Radiance(direction Vec3) Vec3 {
+	return e.gradient.Sample(direction.Y*0.5 + 0.5)
+}
+
+// Perez sky distribution function, theta is the angle between the direction and the zenith while gamma is the
+// angle between the direction and the sun
+func perez(coefficients [5]float64, theta float64, gamma float64) float64 {
+	a, b, c, d, e := coefficients[0], coefficients[1], coefficients[2], coefficients[3], coefficients[4]
+	cosGamma := math.Cos(gamma)
+
+	return (1.0 + a*math.Exp(b/math.Max(math.Cos(theta), 0.01))) * (1.0 + c*math.Exp(d*gamma) + e*cosGamma*cosGamma)
+}
+
+// Calculate the color of the sky, directions below the horizon get the color of the horizon
+func (e *PreethamSky) Radiance(direction Vec3) Vec3 {
+	direction = Normalize(Vec3{X: direction.X, Y: math.Max(direction.Y, 0.0), Z: direction.Z})
+	theta := math.Acos(direction.Y)
+	gamma := math.Acos(ClampBetween(Dot(direction, e.sunDirection), -1.0, 1.0))
+
+	luminance := e.intensity * perez(e.luminance, theta, gamma) / e.perezZenith[0]
+	x := e.zenithChromaX * perez(e.chromaX, theta, gamma) / e.perezZenith[1]
+	y := e.zenithChromaY * perez(e.chromaY, theta, gamma) / e.perezZenith[2]
+
+	// Convert from the CIE xyY color space to linear sRGB
+	cieX := x / y * luminance
+	cieZ := (1.0 - x - y) / y * luminance
+
+	return Vec3{
+		X: math.Max(3.2406*cieX-1.5372*luminance-0.4986*cieZ, 0.0),
+		Y: math.Max(-0.9689*cieX+1.8758*luminance+0.0415*cieZ, 0.0),
+		Z: math.Max(0.0557*cieX-0.2040*luminance+1.0570*cieZ, 0.0),
+	}
+}
+
+// Look up the color of the environment in the image, neighbouring pixels are blended using bilinear filtering
+func (e *EnvironmentMap) Radiance(direction Vec3) Vec3 {
+	width, height := e.image.GetWidth(), e.image.GetHeight()
+	if width == 0 || height == 0 {
+		return Vec3{}
+	}
+
+	u := 0.5 + (math.Atan2(direction.X, -direction.Z)+e.rotation)/(2.0*math.Pi)
+	v := math.Acos(ClampBetween(direction.Y, -1.0, 1.0)) / math.Pi
+
+	x := u*float64(width) - 0.5
+	y := ClampBetween(v*float64(height)-0.5, 0.0, float64(height-1))
+	x0, y0 := math.Floor(x), math.Floor(y)
+	tx, ty := x-x0, y-y0
+
+	// The image wraps around horizontally but not vertically
+	column := func(offset float64) int {
+		return ((int(x0+offset) % width) + width) % width
+	}
+	row := func(offset float64) int {
+		return int(math.Min(y0+offset, float64(height-1)))
+	}
+
+	top := mixVec3(e.image.GetPixel(column(0.0), row(0.0)), e.image.GetPixel(column(1.0), row(0.0)), tx)
+	bottom := mixVec3(e.image.GetPixel(column(0.0), row(1.0)), e.image.GetPixel(column(1.0), row(1.0)), tx)
+
+	return MultiplyScalar(mixVec3(top, bottom, ty), e.intensity)
+}
+
+// Linearly interpolate between two colors
+func mixVec3(a Vec3, b Vec3, t float64) Vec3 {
+	return Add(MultiplyScalar(a, 1.0-t), MultiplyScalar(b, t))
+}
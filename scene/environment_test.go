@@ -0,0 +1,93 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+	. "github.com/tntmeijs/gengo/utility"
+)
+
+func TestSceneBackground(t *testing.T) {
+	scene := NewScene(unitSphere)
+	if background := scene.GetBackground(Vec3{Y: 1.0}); background != (Vec3{}) {
+		t.Fatalf("Environment failure: expected a black background without an environment but got %v", background)
+	}
+
+	scene.SetEnvironment(NewSolidEnvironment(white))
+	if background := scene.GetBackground(Vec3{Y: 5.0}); background != white {
+		t.Fatalf("Environment failure: expected a white background but got %v", background)
+	}
+}
+
+func TestGradientEnvironment(t *testing.T) {
+	gradient := NewGradient(NewColorStop(0.0, Color{Red: 255, Alpha: 255}), NewColorStop(1.0, Color{Blue: 255, Alpha: 255}))
+	environment := NewGradientEnvironment(gradient)
+
+	if down := environment.Radiance(Vec3{Y: -1.0}); down != (Vec3{X: 1.0}) {
+		t.Fatalf("Environment failure: expected red straight down but got %v", down)
+	}
+
+	if horizon := environment.Radiance(Vec3{X: 1.0}); horizon != (Vec3{X: 0.5, Z: 0.5}) {
+		t.Fatalf("Environment failure: expected purple at the horizon but got %v", horizon)
+	}
+}
+
+func TestPreethamSky(t *testing.T) {
+	sunDirection := Normalize(Vec3{X: 1.0, Y: 1.0})
+	sky := NewPreethamSky(sunDirection, 3.0, 1.0)
+	zenith := sky.Radiance(Vec3{Y: 1.0})
+
+	// The luminance straight up matches the intensity and a clear sky is blue
+	if luminance := 0.2126*zenith.X + 0.7152*zenith.Y + 0.0722*zenith.Z; math.Abs(luminance-1.0) > 0.05 {
+		t.Fatalf("Sky failure: expected a luminance of 1.0 at the zenith but got %f", luminance)
+	}
+
+	if zenith.Z <= zenith.X {
+		t.Fatalf("Sky failure: expected a blue sky but got %v", zenith)
+	}
+
+	towardsSun := sky.Radiance(Normalize(Vec3{X: 1.0, Y: 0.8}))
+	awayFromSun := sky.Radiance(Normalize(Vec3{X: -1.0, Y: 0.8}))
+	if Length(towardsSun) <= Length(awayFromSun) {
+		t.Fatalf("Sky failure: expected the sky to be brighter around the sun")
+	}
+}
+
+func TestEnvironmentMap(t *testing.T) {
+	image := NewFramebuffer(4, 2)
+	for x := 0; x < 4; x++ {
+		image.SetPixel(x, 0, Vec3{X: float64(x)}, 1.0)
+		image.SetPixel(x, 1, Vec3{Y: float64(x)}, 1.0)
+	}
+
+	environment := NewEnvironmentMap(image, 2.0, 0.0)
+
+	// Direction through the center of the second pixel on the top row
+	phi := (0.375 - 0.5) * 2.0 * math.Pi
+	sin := math.Sin(math.Pi / 4.0)
+	direction := Vec3{X: math.Sin(phi) * sin, Y: math.Cos(math.Pi / 4.0), Z: -math.Cos(phi) * sin}
+
+	if result := environment.Radiance(direction); !nearlyEqual(result, Vec3{X: 2.0}) {
+		t.Fatalf("Environment map failure: expected {2 0 0} but got %v", result)
+	}
+
+	if result := environment.Radiance(Vec3{Y: -1.0}); result.X != 0.0 || result.Z != 0.0 {
+		t.Fatalf("Environment map failure: expected straight down to use the bottom row but got %v", result)
+	}
+}
+
+func TestEnvironmentLight(t *testing.T) {
+	light := NewEnvironmentLight(NewSolidEnvironment(white), 1.0, 256)
+	normal := Vec3{Y: 1.0}
+	irradiance := 0.0
+
+	for _, sample := range SampleLight(light, Vec3{}) {
+		irradiance += sample.Radiance.X * math.Max(Dot(normal, sample.Direction), 0.0)
+	}
+
+	// A uniform white environment results in an irradiance of pi on a surface
+	if math.Abs(irradiance-math.Pi) > 0.05 {
+		t.Fatalf("Environment light failure: expected an irradiance of %f but got %f", math.Pi, irradiance)
+	}
+}
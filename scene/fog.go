@@ -0,0 +1,173 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Atmospheric effect that absorbs and scatters light travelling along a ray
+type Fog interface {
+	// Evaluate the fog along a ray up to the specified distance. The transmittance is the fraction of the light
+	// at the end of the ray that reaches its origin, the in-scattered light is the light the fog adds along the way.
+	Evaluate(scene *Scene, ray Ray, distance float64) (transmittance Vec3, inScattered Vec3)
+}
+
+// Fog that becomes thicker the further away a surface is
+type DistanceFog struct {
+	Color   Vec3
+	Density float64
+}
+
+// Fog that is thickest at its base height and thins out exponentially above it
+type HeightFog struct {
+	Color                        Vec3
+	Density, Falloff, BaseHeight float64
+}
+
+// Medium that fills the scene and scatters light from the lights in the scene towards the camera. Shadows cast
+// through the medium result in visible shafts of light.
+type ParticipatingMedium struct {
+	Density         float64
+	Albedo          Vec3
+	Anisotropy      float64
+	StepCount       int
+	densityFunction func(point Vec3) float64
+	shadowSettings  ShadowSettings
+}
+
+// Create a new distance fog, the density is the fraction of light absorbed per unit of distance
+//
+// Reference: https://iquilezles.org/articles/fog/
+func NewDistanceFog(color Vec3, density float64) *DistanceFog {
+	return &DistanceFog{color, density}
+}
+
+// Create a new height fog with the following properties:
+//
+//	Color      : linear color of the fog
+//	Density    : density of the fog at its base height
+//	Falloff    : rate at which the density decreases with height
+//	BaseHeight : height at which the fog has the specified density
+//
+// Reference: https://iquilezles.org/articles/fog/
+func NewHeightFog(color Vec3, density float64, falloff float64, baseHeight float64) *HeightFog {
+	return &HeightFog{color, density, falloff, baseHeight}
+}
+
+// Create a new medium with the same density everywhere with the following properties:
+//
+//	Density    : fraction of light absorbed or scattered per unit of distance
+//	Albedo     : fraction of the light hitting the medium that is scattered instead of absorbed
+//	Anisotropy : Henyey-Greenstein asymmetry between -1.0 (back scattering) and 1.0 (forward scattering)
+//	StepCount  : number of samples taken along each ray
+func NewHomogeneousMedium(density float64, albedo Vec3, anisotropy float64, stepCount int, shadowSettings ShadowSettings) *ParticipatingMedium {
+	return NewHeterogeneousMedium(func(point Vec3) float64 { return 1.0 }, density, albedo, anisotropy, stepCount, shadowSettings)
+}
+
+// Create a new medium whose density varies throughout space, the density function is multiplied with the density
+func NewHeterogeneousMedium(densityFunction func(point Vec3) float64, density float64, albedo Vec3, anisotropy float64, stepCount int, shadowSettings ShadowSettings) *ParticipatingMedium {
+	return &ParticipatingMedium{density, albedo, ClampBetween(anisotropy, -0.999, 0.999), int(math.Max(float64(stepCount), 1.0)), densityFunction, shadowSettings}
+}
+
+// Blend from the transmittance to the color of the fog as the fog absorbs more light
+func uniformFog(color Vec3, opticalDepth float64) (Vec3, Vec3) {
+	transmittance := math.Exp(-opticalDepth)
+	return Vec3{X: transmittance, Y: transmittance, Z: transmittance}, MultiplyScalar(color, 1.0-transmittance)
+}
+
+// Evaluate the distance fog along a ray
+func (f *DistanceFog) Evaluate(scene *Scene, ray Ray, distance float64) (Vec3, Vec3) {
+	return uniformFog(f.Color, f.Density*distance)
+}
+
+// Evaluate the height fog along a ray by integrating its density analytically
+func (f *HeightFog) Evaluate(scene *Scene, ray Ray, distance float64) (Vec3, Vec3) {
+	direction := Normalize(ray.Direction)
+	densityAtOrigin := f.Density * math.Exp(-f.Falloff*(ray.Origin.Y-f.BaseHeight))
+	heightChange := f.Falloff * direction.Y * distance
+
+	// A ray that stays at the same height travels through fog of a constant density
+	opticalDepth := densityAtOrigin * distance
+	if math.Abs(heightChange) > 1e-6 {
+		opticalDepth *= (1.0 - math.Exp(-heightChange)) / heightChange
+	}
+
+	return uniformFog(f.Color, opticalDepth)
+}
+
+// Henyey-Greenstein phase function, the fraction of light arriving along one direction that is scattered into
+// another direction, the cosine is taken between the two directions
+func henyeyGreenstein(cosTheta float64, anisotropy float64) float64 {
+	g := anisotropy
+	denominator := 1.0 + g*g - 2.0*g*cosTheta
+
+	return (1.0 - g*g) / (4.0 * math.Pi * denominator * math.Sqrt(denominator))
+}
+
+// Evaluate the medium by marching along the ray and gathering the light scattered towards the origin of the ray
+// by every sample, only a single scattering event is taken into account
+//
+// Reference: https://www.scratchapixel.com/lessons/3d-basic-rendering/volume-rendering-for-developers/intro-volume-rendering.html
+func (m *ParticipatingMedium) Evaluate(scene *Scene, ray Ray, distance float64) (Vec3, Vec3) {
+	direction := Normalize(ray.Direction)
+	distance = math.Min(distance, m.shadowSettings.MaxDistance)
+	stepLength := distance / float64(m.StepCount)
+	transmittance := 1.0
+	inScattered := Vec3{}
+
+	for i := 0; i < m.StepCount; i++ {
+		point := Add(ray.Origin, MultiplyScalar(direction, (float64(i)+0.5)*stepLength))
+		density := m.Density * m.densityFunction(point)
+
+		if density <= 0.0 {
+			continue
+		}
+
+		incoming := Vec3{}
+		for _, light := range scene.GetLights() {
+			sample := light.Sample(point, 0.5, 0.5)
+			shadow := scene.ShadowFactor(point, Vec3{}, sample, 0.0, m.shadowSettings)
+			incoming.Add(MultiplyScalar(sample.Radiance, shadow*henyeyGreenstein(Dot(direction, sample.Direction), m.Anisotropy)))
+		}
+
+		// Integrate the scattered light over the step analytically, which keeps the result stable for large steps
+		stepTransmittance := math.Exp(-density * stepLength)
+		inScattered.Add(MultiplyScalar(Multiply(incoming, m.Albedo), transmittance*(1.0-stepTransmittance)))
+		transmittance *= stepTransmittance
+	}
+
+	return Vec3{X: transmittance, Y: transmittance, Z: transmittance}, inScattered
+}
+
+// Add an atmospheric effect to the scene, multiple effects are applied in the order they were added
+func (s *Scene) AddFog(fog Fog) {
+	s.fog = append(s.fog, fog)
+}
+
+// Check whether the scene contains any atmospheric effects
+func (s *Scene) HasFog() bool {
+	return len(s.fog) > 0
+}
+
+// Evaluate all atmospheric effects in the scene along a ray. Effects are layered on top of each other, the effects
+// added first are considered to be closest to the origin of the ray.
+func (s *Scene) EvaluateFog(ray Ray, distance float64) (Vec3, Vec3) {
+	transmittance := Vec3{X: 1.0, Y: 1.0, Z: 1.0}
+	inScattered := Vec3{}
+
+	for _, fog := range s.fog {
+		fogTransmittance, fogInScattered := fog.Evaluate(s, ray, distance)
+		inScattered.Add(Multiply(transmittance, fogInScattered))
+		transmittance = Multiply(transmittance, fogTransmittance)
+	}
+
+	return transmittance, inScattered
+}
+
+// Apply all atmospheric effects in the scene to the color at the end of a ray, the distance is measured in world
+// units. Rays that did not hit anything should use the maximum distance, no matter why the ray march stopped.
+func (s *Scene) ApplyFog(ray Ray, distance float64, color Vec3) Vec3 {
+	transmittance, inScattered := s.EvaluateFog(ray, distance)
+	return Add(Multiply(color, transmittance), inScattered)
+}
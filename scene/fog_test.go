@@ -0,0 +1,101 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Scene without any surfaces within reach of the fog
+func emptyScene() Scene {
+	return NewScene(func(point Vec3) float64 { return PlaneSDF(point, Vec3{Y: 1.0}, 1000.0) })
+}
+
+func TestDistanceFog(t *testing.T) {
+	scene := emptyScene()
+	scene.AddFog(NewDistanceFog(white, 0.5))
+	result := scene.ApplyFog(Ray{Direction: Vec3{Z: 2.0}}, 2.0, Vec3{})
+
+	if expected := 1.0 - math.Exp(-1.0); math.Abs(result.X-expected) > tolerance {
+		t.Fatalf("Fog failure: expected %f but got %f", expected, result.X)
+	}
+
+	clear := emptyScene()
+	if !scene.HasFog() || clear.HasFog() {
+		t.Fatalf("Fog failure: only scenes with fog should report having fog")
+	}
+}
+
+func TestFogOnMiss(t *testing.T) {
+	// A ray parallel to a plane runs out of iterations after two units, the fog should still reach the far plane
+	scene := NewScene(func(point Vec3) float64 { return point.Y })
+	scene.AddFog(NewDistanceFog(white, 0.5))
+	tracer := NewRecursiveTracer(NewRecursiveTracerSettings(0, 0.002), NewSphereTraceSettings(1.0, 0.001, 4), wallShader)
+	result := tracer.Trace(&scene, Ray{Origin: Vec3{Y: 0.5}, Direction: Vec3{X: 1.0}}, 4.0)
+
+	if expected := 1.0 - math.Exp(-2.0); math.Abs(result.X-expected) > tolerance {
+		t.Fatalf("Fog failure: expected %f but got %f", expected, result.X)
+	}
+}
+
+func TestHeightFog(t *testing.T) {
+	scene := emptyScene()
+	fog := NewHeightFog(white, 0.5, 1.0, 0.0)
+	distance := NewDistanceFog(white, 0.5)
+
+	// A horizontal ray at the base height sees the same fog as distance fog
+	horizontal := Ray{Direction: Vec3{X: 1.0}}
+	heightTransmittance, _ := fog.Evaluate(&scene, horizontal, 3.0)
+	distanceTransmittance, _ := distance.Evaluate(&scene, horizontal, 3.0)
+
+	if math.Abs(heightTransmittance.X-distanceTransmittance.X) > tolerance {
+		t.Fatalf("Height fog failure: expected %f but got %f", distanceTransmittance.X, heightTransmittance.X)
+	}
+
+	// Rays that travel upwards leave the fog behind them
+	upwards, _ := fog.Evaluate(&scene, Ray{Direction: Vec3{Y: 1.0}}, 3.0)
+	if expected := math.Exp(-0.5 * (1.0 - math.Exp(-3.0))); math.Abs(upwards.X-expected) > tolerance {
+		t.Fatalf("Height fog failure: expected %f but got %f", expected, upwards.X)
+	}
+}
+
+func TestHenyeyGreensteinIsNormalized(t *testing.T) {
+	for _, anisotropy := range []float64{-0.5, 0.0, 0.8} {
+		integral := 0.0
+		steps := 10000
+
+		// Integrate over the sphere, the phase function only depends on the polar angle
+		for i := 0; i < steps; i++ {
+			theta := (float64(i) + 0.5) / float64(steps) * math.Pi
+			integral += henyeyGreenstein(math.Cos(theta), anisotropy) * 2.0 * math.Pi * math.Sin(theta) * math.Pi / float64(steps)
+		}
+
+		if math.Abs(integral-1.0) > 0.001 {
+			t.Fatalf("Phase function failure: expected an integral of 1.0 for anisotropy %f but got %f", anisotropy, integral)
+		}
+	}
+}
+
+func TestHomogeneousMedium(t *testing.T) {
+	scene := emptyScene()
+	medium := NewHomogeneousMedium(0.25, white, 0.0, 16, testShadowSettings)
+	ray := Ray{Direction: Vec3{X: 1.0}}
+
+	transmittance, inScattered := medium.Evaluate(&scene, ray, 4.0)
+	if math.Abs(transmittance.X-math.Exp(-1.0)) > tolerance || inScattered != (Vec3{}) {
+		t.Fatalf("Medium failure: expected a transmittance of %f without lights but got %v and %v", math.Exp(-1.0), transmittance, inScattered)
+	}
+
+	// Light is scattered towards the camera, unless a surface casts a shadow into the medium
+	scene.AddLight(NewDirectionalLight(Vec3{Y: -1.0}, white, 1.0))
+	_, lit := medium.Evaluate(&scene, ray, 4.0)
+
+	shadowed := NewScene(func(point Vec3) float64 { return PlaneSDF(point, Vec3{Y: -1.0}, -1.0) })
+	shadowed.AddLight(NewDirectionalLight(Vec3{Y: -1.0}, white, 1.0))
+	_, dark := medium.Evaluate(&shadowed, ray, 4.0)
+
+	if lit.X <= 0.0 || dark.X != 0.0 {
+		t.Fatalf("Medium failure: expected light to scatter only without a shadow but got %v and %v", lit, dark)
+	}
+}
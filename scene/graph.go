@@ -0,0 +1,290 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// A node in a scene graph, every node evaluates to the signed distance from a point to its surface. Nodes can
+// be evaluated with or without looking up the material of the closest surface.
+type Node interface {
+	Distance(point Vec3) float64
+	Evaluate(point Vec3) (float64, MaterialID)
+}
+
+// Leaf node that wraps a signed distance function
+type PrimitiveNode struct {
+	shape sdf
+}
+
+// Assigns a material to every surface of its child
+type MaterialNode struct {
+	child    Node
+	material MaterialID
+}
+
+// Combines any number of nodes by taking the union of their surfaces
+type UnionNode struct {
+	children []Node
+}
+
+// Keeps only the volume where all nodes overlap
+type IntersectionNode struct {
+	children []Node
+}
+
+// Carves a node out of another node
+type SubtractionNode struct {
+	base, subtracted Node
+}
+
+// Union of two nodes that blends the surfaces where they meet
+type SmoothUnionNode struct {
+	a, b       Node
+	smoothness float64
+}
+
+// Intersection of two nodes that blends the surfaces where they meet
+type SmoothIntersectionNode struct {
+	a, b       Node
+	smoothness float64
+}
+
+// Subtraction of two nodes that blends the surfaces where they meet
+type SmoothSubtractionNode struct {
+	base, subtracted Node
+	smoothness       float64
+}
+
+// Places a node in space by translating, rotating, and uniformly scaling it
+type TransformNode struct {
+	child     Node
+	transform Transform
+}
+
+// Create a new scene from the root node of a scene graph, the material nodes in the graph refer to the materials
+// by their index
+func NewSceneFromGraph(root Node, materials ...Material) Scene {
+	scene := NewMaterialScene(root.Evaluate, materials)
+	scene.sceneSDF = root.Distance
+	return scene
+}
+
+// Create a new leaf node from any signed distance function
+func NewPrimitiveNode(shape sdf) *PrimitiveNode {
+	return &PrimitiveNode{shape}
+}
+
+// Create a new leaf node containing a sphere centered on the origin
+func NewSphereNode(radius float64) *PrimitiveNode {
+	return NewPrimitiveNode(func(point Vec3) float64 {
+		return SphereSDF(point, radius)
+	})
+}
+
+// Create a new leaf node containing a Mandelbulb fractal centered on the origin
+func NewMandelbulbNode(iterations int, power int, bailout float64) *PrimitiveNode {
+	return NewPrimitiveNode(func(point Vec3) float64 {
+		return MandelbulbSDF(point, iterations, power, bailout)
+	})
+}
+
+// Create a new material node that assigns the material to its child
+func NewMaterialNode(child Node, material MaterialID) *MaterialNode {
+	return &MaterialNode{child, material}
+}
+
+// Create a new union node
+func NewUnionNode(children ...Node) *UnionNode {
+	return &UnionNode{children}
+}
+
+// Create a new intersection node
+func NewIntersectionNode(children ...Node) *IntersectionNode {
+	return &IntersectionNode{children}
+}
+
+// Create a new subtraction node that removes the subtracted node from the base node
+func NewSubtractionNode(base Node, subtracted Node) *SubtractionNode {
+	return &SubtractionNode{base, subtracted}
+}
+
+// Create a new smooth union node, the smoothness controls the size of the blend region
+func NewSmoothUnionNode(a Node, b Node, smoothness float64) *SmoothUnionNode {
+	return &SmoothUnionNode{a, b, smoothness}
+}
+
+// Create a new smooth intersection node, the smoothness controls the size of the blend region
+func NewSmoothIntersectionNode(a Node, b Node, smoothness float64) *SmoothIntersectionNode {
+	return &SmoothIntersectionNode{a, b, smoothness}
+}
+
+// Create a new smooth subtraction node, the smoothness controls the size of the blend region
+func NewSmoothSubtractionNode(base Node, subtracted Node, smoothness float64) *SmoothSubtractionNode {
+	return &SmoothSubtractionNode{base, subtracted, smoothness}
+}
+
+// Create a new transform node that places its child in space, the scale is kept above zero
+func NewTransformNode(child Node, transform Transform) *TransformNode {
+	transform.Scale = validScale(transform.Scale)
+	return &TransformNode{child, transform}
+}
+
+// Distance to the wrapped signed distance function
+func (n *PrimitiveNode) Distance(point Vec3) float64 {
+	return n.shape(point)
+}
+
+// Distance to the wrapped signed distance function, primitives use the first material unless a material node
+// assigns a different one
+func (n *PrimitiveNode) Evaluate(point Vec3) (float64, MaterialID) {
+	return n.shape(point), 0
+}
+
+// Distance to the child
+func (n *MaterialNode) Distance(point Vec3) float64 {
+	return n.child.Distance(point)
+}
+
+// Distance to the child with the material of this node
+func (n *MaterialNode) Evaluate(point Vec3) (float64, MaterialID) {
+	return n.child.Distance(point), n.material
+}
+
+// Distance to the closest child
+func (n *UnionNode) Distance(point Vec3) float64 {
+	distance := math.Inf(1)
+
+	for _, child := range n.children {
+		distance = math.Min(distance, child.Distance(point))
+	}
+
+	return distance
+}
+
+// Distance to the closest child along with its material
+func (n *UnionNode) Evaluate(point Vec3) (float64, MaterialID) {
+	distance := math.Inf(1)
+	material := MaterialID(0)
+
+	for _, child := range n.children {
+		childDistance, childMaterial := child.Evaluate(point)
+
+		if childDistance < distance {
+			distance = childDistance
+			material = childMaterial
+		}
+	}
+
+	return distance, material
+}
+
+// Distance to the furthest child
+func (n *IntersectionNode) Distance(point Vec3) float64 {
+	distance := math.Inf(-1)
+
+	for _, child := range n.children {
+		distance = math.Max(distance, child.Distance(point))
+	}
+
+	return distance
+}
+
+// Distance to the furthest child along with its material
+func (n *IntersectionNode) Evaluate(point Vec3) (float64, MaterialID) {
+	distance := math.Inf(-1)
+	material := MaterialID(0)
+
+	for _, child := range n.children {
+		childDistance, childMaterial := child.Evaluate(point)
+
+		if childDistance > distance {
+			distance = childDistance
+			material = childMaterial
+		}
+	}
+
+	return distance, material
+}
+
+// Distance to the base node with the subtracted node carved out
+func (n *SubtractionNode) Distance(point Vec3) float64 {
+	return math.Max(n.base.Distance(point), -n.subtracted.Distance(point))
+}
+
+// Distance to the base node with the subtracted node carved out, carved surfaces get the material of the
+// subtracted node
+func (n *SubtractionNode) Evaluate(point Vec3) (float64, MaterialID) {
+	baseDistance, baseMaterial := n.base.Evaluate(point)
+	subtractedDistance, subtractedMaterial := n.subtracted.Evaluate(point)
+
+	if -subtractedDistance > baseDistance {
+		return -subtractedDistance, subtractedMaterial
+	}
+
+	return baseDistance, baseMaterial
+}
+
+// Smoothly blended distance to the closest child
+func (n *SmoothUnionNode) Distance(point Vec3) float64 {
+	return SmoothMin(n.a.Distance(point), n.b.Distance(point), n.smoothness)
+}
+
+// Smoothly blended distance to the closest child, the material is taken from the child that contributes the
+// most to the blended surface
+func (n *SmoothUnionNode) Evaluate(point Vec3) (float64, MaterialID) {
+	distanceA, materialA := n.a.Evaluate(point)
+	distanceB, materialB := n.b.Evaluate(point)
+
+	return SmoothMin(distanceA, distanceB, n.smoothness), closestMaterial(distanceA, materialA, distanceB, materialB)
+}
+
+// Smoothly blended distance to the furthest child
+func (n *SmoothIntersectionNode) Distance(point Vec3) float64 {
+	return SmoothMax(n.a.Distance(point), n.b.Distance(point), n.smoothness)
+}
+
+// Smoothly blended distance to the furthest child, the material is taken from the child that contributes the
+// most to the blended surface
+func (n *SmoothIntersectionNode) Evaluate(point Vec3) (float64, MaterialID) {
+	distanceA, materialA := n.a.Evaluate(point)
+	distanceB, materialB := n.b.Evaluate(point)
+
+	return SmoothMax(distanceA, distanceB, n.smoothness), closestMaterial(-distanceA, materialA, -distanceB, materialB)
+}
+
+// Smoothly blended distance to the base node with the subtracted node carved out
+func (n *SmoothSubtractionNode) Distance(point Vec3) float64 {
+	return SmoothMax(n.base.Distance(point), -n.subtracted.Distance(point), n.smoothness)
+}
+
+// Smoothly blended distance to the base node with the subtracted node carved out, the material is taken from
+// the node that contributes the most to the blended surface
+func (n *SmoothSubtractionNode) Evaluate(point Vec3) (float64, MaterialID) {
+	baseDistance, baseMaterial := n.base.Evaluate(point)
+	subtractedDistance, subtractedMaterial := n.subtracted.Evaluate(point)
+
+	return SmoothMax(baseDistance, -subtractedDistance, n.smoothness), closestMaterial(-baseDistance, baseMaterial, subtractedDistance, subtractedMaterial)
+}
+
+// Distance to the child after moving the point into the child's local space, the distance is scaled back
+// afterwards to keep it a correct distance in world space
+func (n *TransformNode) Distance(point Vec3) float64 {
+	return n.child.Distance(n.transform.ToLocalSpace(point)) * n.transform.Scale
+}
+
+// Distance to the child along with its material
+func (n *TransformNode) Evaluate(point Vec3) (float64, MaterialID) {
+	distance, material := n.child.Evaluate(n.transform.ToLocalSpace(point))
+	return distance * n.transform.Scale, material
+}
+
+// Pick the material of whichever distance is the smallest
+func closestMaterial(distanceA float64, materialA MaterialID, distanceB float64, materialB MaterialID) MaterialID {
+	if distanceA <= distanceB {
+		return materialA
+	}
+
+	return materialB
+}
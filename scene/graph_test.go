@@ -0,0 +1,99 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+func TestGraphNodeDistances(t *testing.T) {
+	// Two unit spheres that overlap between x = 0.0 and x = 1.0
+	left := NewSphereNode(1.0)
+	right := NewTransformNode(NewSphereNode(1.0), NewTransform(Vec3{X: 1.0}, IdentityQuaternion(), 1.0))
+
+	samples := []struct {
+		name     string
+		node     Node
+		point    Vec3
+		distance float64
+	}{
+		{"UnionNode", NewUnionNode(left, right), Vec3{X: -2.0}, 1.0},
+		{"UnionNode", NewUnionNode(left, right), Vec3{X: 0.5}, -0.5},
+		{"IntersectionNode", NewIntersectionNode(left, right), Vec3{X: -2.0}, 2.0},
+		{"IntersectionNode", NewIntersectionNode(left, right), Vec3{X: 0.5}, -0.5},
+		{"SubtractionNode", NewSubtractionNode(left, right), Vec3{X: -2.0}, 1.0},
+		{"SubtractionNode", NewSubtractionNode(left, right), Vec3{X: 0.5}, 0.5},
+		{"SmoothUnionNode", NewSmoothUnionNode(left, right, 0.5), Vec3{X: -2.0}, 1.0},
+		{"SmoothUnionNode", NewSmoothUnionNode(left, right, 0.5), Vec3{X: 0.5}, -0.625},
+		{"SmoothIntersectionNode", NewSmoothIntersectionNode(left, right, 0.5), Vec3{X: -2.0}, 2.0},
+		{"SmoothIntersectionNode", NewSmoothIntersectionNode(left, right, 0.5), Vec3{X: 0.5}, -0.375},
+		{"SmoothSubtractionNode", NewSmoothSubtractionNode(left, right, 0.5), Vec3{X: -2.0}, 1.0},
+		{"SmoothSubtractionNode", NewSmoothSubtractionNode(left, right, 0.5), Vec3{X: 1.5}, 0.625},
+		{"TransformNode", NewTransformNode(left, NewTransform(Vec3{X: 1.0}, IdentityQuaternion(), 2.0)), Vec3{X: 4.0}, 1.0},
+		{"TransformNode", NewTransformNode(left, NewTransform(Vec3{X: 1.0}, IdentityQuaternion(), 2.0)), Vec3{X: 1.0}, -2.0},
+	}
+
+	for _, sample := range samples {
+		distance := sample.node.Distance(sample.point)
+
+		if math.Abs(distance-sample.distance) > 0.0001 {
+			t.Fatalf("%s failure: expected a distance of %f at %v but got %f", sample.name, sample.distance, sample.point, distance)
+		}
+	}
+}
+
+func TestTransformNodeZeroScale(t *testing.T) {
+	node := NewTransformNode(NewSphereNode(1.0), NewTransform(Vec3{}, IdentityQuaternion(), 0.0))
+	distance := node.Distance(Vec3{X: 1.0})
+
+	if math.IsNaN(distance) || math.IsInf(distance, 0) || distance <= 0.0 {
+		t.Fatalf("TransformNode failure: expected a zero scale to still produce a positive distance but got %f", distance)
+	}
+}
+
+func TestUnionNodeMaterial(t *testing.T) {
+	left := NewMaterialNode(NewTransformNode(NewSphereNode(1.0), NewTransform(Vec3{X: -5.0}, IdentityQuaternion(), 1.0)), 1)
+	right := NewMaterialNode(NewTransformNode(NewSphereNode(1.0), NewTransform(Vec3{X: 5.0}, IdentityQuaternion(), 1.0)), 2)
+	union := NewUnionNode(left, right)
+
+	distance, material := union.Evaluate(Vec3{X: 4.0})
+	if material != 2 || distance != union.Distance(Vec3{X: 4.0}) {
+		t.Fatalf("Union failure: expected material 2 but got %d", material)
+	}
+
+	_, material = union.Evaluate(Vec3{X: -3.0})
+	if material != 1 {
+		t.Fatalf("Union failure: expected material 1 but got %d", material)
+	}
+}
+
+func TestSubtractionNodeMaterial(t *testing.T) {
+	box := NewMaterialNode(NewPrimitiveNode(func(point Vec3) float64 { return BoxSDF(point, Vec3{X: 1.0, Y: 1.0, Z: 1.0}) }), 1)
+	hole := NewMaterialNode(NewSphereNode(0.5), 2)
+	subtraction := NewSubtractionNode(box, hole)
+
+	// The inside of the hole is lined with the material of the subtracted node
+	distance, material := subtraction.Evaluate(Vec3{X: 0.25})
+	if material != 2 || distance != 0.25 {
+		t.Fatalf("Subtraction failure: expected material 2 at a distance of 0.25 but got %d at %f", material, distance)
+	}
+
+	_, material = subtraction.Evaluate(Vec3{X: 2.0})
+	if material != 1 {
+		t.Fatalf("Subtraction failure: expected material 1 but got %d", material)
+	}
+}
+
+func TestSceneGetMaterial(t *testing.T) {
+	red := NewMaterial(Vec3{X: 1.0}, 0.5, 0.0)
+	scene := NewSceneFromGraph(NewMaterialNode(NewSphereNode(1.0), 0), red)
+
+	if scene.GetMaterial(0) != red {
+		t.Fatalf("Material failure: expected the first material to be %v", red)
+	}
+
+	if scene.GetMaterial(5) != DefaultMaterial() {
+		t.Fatalf("Material failure: unknown material IDs should fall back to the default material")
+	}
+}
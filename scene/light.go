@@ -0,0 +1,265 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Light arriving at a surface point from a single sample on a light source
+type LightSample struct {
+	Direction Vec3
+	Distance  float64
+	Radiance  Vec3
+}
+
+// Properties that all light sources share, a shadow softness of zero results in hard shadows
+type LightProperties struct {
+	Color          Vec3
+	Intensity      float64
+	ShadowSoftness float64
+}
+
+// A light source that illuminates a scene
+type Light interface {
+	// Sample the light as seen from a point in space, u and v are in the range [0.0, 1.0) and select the point
+	// on the surface of area lights
+	Sample(point Vec3, u float64, v float64) LightSample
+
+	// Number of samples needed to approximate the light, this is 1 for lights that are infinitely small
+	SampleCount() int
+
+	// Get the properties shared between all light sources
+	GetProperties() LightProperties
+}
+
+// Distance attenuation of a light: 1.0 / (constant + linear * distance + quadratic * distance^2)
+type Attenuation struct {
+	Constant, Linear, Quadratic float64
+}
+
+// Light that shines equally in all directions from a single point
+type PointLight struct {
+	LightProperties
+	Position    Vec3
+	Attenuation Attenuation
+}
+
+// Light that shines in a single direction from infinitely far away, like the sun
+type DirectionalLight struct {
+	LightProperties
+	Direction Vec3
+}
+
+// Light that shines from a single point in a cone
+type SpotLight struct {
+	LightProperties
+	Position, Direction          Vec3
+	Attenuation                  Attenuation
+	cosInnerAngle, cosOuterAngle float64
+}
+
+// Light emitted from the surface of a sphere
+type SphereLight struct {
+	LightProperties
+	Center  Vec3
+	Radius  float64
+	Samples int
+}
+
+// Light emitted from one side of a rectangle
+type RectangleLight struct {
+	LightProperties
+	Samples                      int
+	corner, edgeU, edgeV, normal Vec3
+}
+
+// Attenuation that keeps the intensity of a light constant regardless of the distance
+func NoAttenuation() Attenuation {
+	return Attenuation{1.0, 0.0, 0.0}
+}
+
+// Attenuation that follows the physically correct inverse square law
+func InverseSquareAttenuation() Attenuation {
+	return Attenuation{0.0, 0.0, 1.0}
+}
+
+// Calculate the factor the intensity of a light is multiplied with at the specified distance
+func (a *Attenuation) Factor(distance float64) float64 {
+	return 1.0 / math.Max(a.Constant+a.Linear*distance+a.Quadratic*distance*distance, epsilon)
+}
+
+// Create a new point light
+func NewPointLight(position Vec3, color Vec3, intensity float64, attenuation Attenuation) *PointLight {
+	return &PointLight{LightProperties{Color: color, Intensity: intensity}, position, attenuation}
+}
+
+// Create a new directional light, the direction is the direction the light travels in
+func NewDirectionalLight(direction Vec3, color Vec3, intensity float64) *DirectionalLight {
+	return &DirectionalLight{LightProperties{Color: color, Intensity: intensity}, Normalize(direction)}
+}
+
+// Create a new spot light with the following properties:
+//
+//	Position   : position of the light in space
+//	Direction  : direction the cone of the light points in
+//	InnerAngle : angle between the direction and the edge of the cone where the light starts to fade out, in radians
+//	OuterAngle : angle between the direction and the edge of the cone where the light has faded out, in radians
+func NewSpotLight(position Vec3, direction Vec3, innerAngle float64, outerAngle float64, color Vec3, intensity float64, attenuation Attenuation) *SpotLight {
+	outerAngle = math.Max(outerAngle, innerAngle)
+
+	return &SpotLight{
+		LightProperties: LightProperties{Color: color, Intensity: intensity},
+		Position:        position,
+		Direction:       Normalize(direction),
+		Attenuation:     attenuation,
+		cosInnerAngle:   math.Cos(innerAngle),
+		cosOuterAngle:   math.Cos(outerAngle),
+	}
+}
+
+// Create a new spherical area light, more samples result in smoother lighting at the cost of performance
+func NewSphereLight(center Vec3, radius float64, color Vec3, intensity float64, samples int) *SphereLight {
+	return &SphereLight{LightProperties{Color: color, Intensity: intensity}, center, radius, samples}
+}
+
+// Create a new rectangular area light spanned by two edges starting at the corner. The light is emitted from the
+// side the cross product of the edges points towards.
+func NewRectangleLight(corner Vec3, edgeU Vec3, edgeV Vec3, color Vec3, intensity float64, samples int) *RectangleLight {
+	return &RectangleLight{LightProperties{Color: color, Intensity: intensity}, samples, corner, edgeU, edgeV, Normalize(Cross(edgeU, edgeV))}
+}
+
+// Get the properties shared between all light sources
+func (l *LightProperties) GetProperties() LightProperties {
+	return *l
+}
+
+// Color of the light multiplied by its intensity
+func (l *LightProperties) radiance() Vec3 {
+	return MultiplyScalar(l.Color, l.Intensity)
+}
+
+// Sample the light arriving at a point from a position in space, the radiance still has to be attenuated
+func sampleFromPosition(point Vec3, position Vec3, radiance Vec3) LightSample {
+	toLight := Sub(position, point)
+	distance := toLight.MagnitudeSqrt()
+
+	return LightSample{MultiplyScalar(toLight, 1.0/distance), distance, radiance}
+}
+
+// Sample the light arriving at a point
+func (l *PointLight) Sample(point Vec3, u float64, v float64) LightSample {
+	sample := sampleFromPosition(point, l.Position, l.radiance())
+	sample.Radiance.MultiplyWith(l.Attenuation.Factor(sample.Distance))
+	return sample
+}
+
+// Point lights are infinitely small and need a single sample
+func (l *PointLight) SampleCount() int {
+	return 1
+}
+
+// Sample the light arriving at a point
+func (l *DirectionalLight) Sample(point Vec3, u float64, v float64) LightSample {
+	return LightSample{Negate(l.Direction), math.Inf(1), l.radiance()}
+}
+
+// Directional lights are infinitely far away and need a single sample
+func (l *DirectionalLight) SampleCount() int {
+	return 1
+}
+
+// Sample the light arriving at a point, the light fades out smoothly between the inner and the outer angle
+func (l *SpotLight) Sample(point Vec3, u float64, v float64) LightSample {
+	sample := sampleFromPosition(point, l.Position, l.radiance())
+	cosAngle := Dot(Negate(sample.Direction), l.Direction)
+
+	cone := 1.0
+	if l.cosInnerAngle > l.cosOuterAngle {
+		cone = ClampBetween((cosAngle-l.cosOuterAngle)/(l.cosInnerAngle-l.cosOuterAngle), 0.0, 1.0)
+	} else if cosAngle < l.cosOuterAngle {
+		cone = 0.0
+	}
+
+	sample.Radiance.MultiplyWith(cone * cone * (3.0 - 2.0*cone) * l.Attenuation.Factor(sample.Distance))
+	return sample
+}
+
+// Spot lights are infinitely small and need a single sample
+func (l *SpotLight) SampleCount() int {
+	return 1
+}
+
+// Sample the light arriving at a point from a point on the disc of the sphere that faces the point. Each sample
+// is treated as a point light that follows the inverse square law.
+func (l *SphereLight) Sample(point Vec3, u float64, v float64) LightSample {
+	toCenter := Normalize(Sub(l.Center, point))
+	tangent, bitangent := orthonormalBasis(toCenter)
+
+	// Map the square onto the disc, the square root keeps the samples uniformly distributed over its area
+	radius := l.Radius * math.Sqrt(u)
+	sin, cos := math.Sincos(2.0 * math.Pi * v)
+	position := AddAll(l.Center, MultiplyScalar(tangent, radius*cos), MultiplyScalar(bitangent, radius*sin))
+
+	sample := sampleFromPosition(point, position, l.radiance())
+	sample.Radiance.MultiplyWith(1.0 / math.Max(sample.Distance*sample.Distance, epsilon))
+	return sample
+}
+
+// Number of samples taken on the surface of the sphere
+func (l *SphereLight) SampleCount() int {
+	return l.Samples
+}
+
+// Sample the light arriving at a point from a point on the rectangle, light leaving the rectangle at a grazing
+// angle is weaker than light leaving it head-on
+func (l *RectangleLight) Sample(point Vec3, u float64, v float64) LightSample {
+	position := AddAll(l.corner, MultiplyScalar(l.edgeU, u), MultiplyScalar(l.edgeV, v))
+	sample := sampleFromPosition(point, position, l.radiance())
+
+	cosLight := math.Max(Dot(l.normal, Negate(sample.Direction)), 0.0)
+	sample.Radiance.MultiplyWith(cosLight / math.Max(sample.Distance*sample.Distance, epsilon))
+	return sample
+}
+
+// Number of samples taken on the surface of the rectangle
+func (l *RectangleLight) SampleCount() int {
+	return l.Samples
+}
+
+// Build two vectors that are perpendicular to the normal and to each other
+func orthonormalBasis(normal Vec3) (Vec3, Vec3) {
+	up := Vec3{X: 0.0, Y: 1.0, Z: 0.0}
+	if math.Abs(normal.Y) > 0.999 {
+		up = Vec3{X: 1.0, Y: 0.0, Z: 0.0}
+	}
+
+	tangent := Normalize(Cross(up, normal))
+	return tangent, Cross(normal, tangent)
+}
+
+// Take all samples of a light using stratified sampling, the radiance of each sample has already been divided by
+// the number of samples so the samples can simply be added together
+func SampleLight(light Light, point Vec3) []LightSample {
+	count := light.SampleCount()
+	if count <= 1 {
+		return []LightSample{light.Sample(point, 0.5, 0.5)}
+	}
+
+	// The rows only cover the samples that are actually taken, so the grid spans the whole light when the count
+	// is not a square
+	columns := int(math.Ceil(math.Sqrt(float64(count))))
+	rows := (count + columns - 1) / columns
+	samples := make([]LightSample, 0, count)
+
+	for i := 0; i < count; i++ {
+		u := (float64(i%columns) + 0.5) / float64(columns)
+		v := (float64(i/columns) + 0.5) / float64(rows)
+
+		sample := light.Sample(point, u, v)
+		sample.Radiance.MultiplyWith(1.0 / float64(count))
+		samples = append(samples, sample)
+	}
+
+	return samples
+}
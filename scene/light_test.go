@@ -0,0 +1,119 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+var white = Vec3{X: 1.0, Y: 1.0, Z: 1.0}
+
+func TestPointLightAttenuation(t *testing.T) {
+	light := NewPointLight(Vec3{X: 0.0, Y: 2.0, Z: 0.0}, white, 8.0, InverseSquareAttenuation())
+	sample := light.Sample(Vec3{}, 0.5, 0.5)
+
+	if sample.Distance != 2.0 || sample.Direction != (Vec3{X: 0.0, Y: 1.0, Z: 0.0}) {
+		t.Fatalf("Point light failure: expected the light to be 2.0 units straight up but got %v", sample)
+	}
+
+	if math.Abs(sample.Radiance.X-2.0) > tolerance {
+		t.Fatalf("Point light failure: expected a radiance of 2.0 but got %f", sample.Radiance.X)
+	}
+}
+
+func TestDirectionalLight(t *testing.T) {
+	light := NewDirectionalLight(Vec3{X: 0.0, Y: -2.0, Z: 0.0}, white, 1.0)
+	sample := light.Sample(Vec3{X: 100.0}, 0.5, 0.5)
+
+	if sample.Direction != (Vec3{X: 0.0, Y: 1.0, Z: 0.0}) || !math.IsInf(sample.Distance, 1) {
+		t.Fatalf("Directional light failure: expected the light to come from infinitely far above but got %v", sample)
+	}
+}
+
+func TestSpotLightCone(t *testing.T) {
+	light := NewSpotLight(Vec3{X: 0.0, Y: 1.0, Z: 0.0}, Vec3{X: 0.0, Y: -1.0, Z: 0.0}, math.Pi/8.0, math.Pi/4.0, white, 1.0, NoAttenuation())
+
+	if inside := light.Sample(Vec3{}, 0.5, 0.5); inside.Radiance.X != 1.0 {
+		t.Fatalf("Spot light failure: expected full intensity inside of the cone but got %f", inside.Radiance.X)
+	}
+
+	if outside := light.Sample(Vec3{X: 2.0}, 0.5, 0.5); outside.Radiance.X != 0.0 {
+		t.Fatalf("Spot light failure: expected no light outside of the cone but got %f", outside.Radiance.X)
+	}
+
+	if edge := light.Sample(Vec3{X: math.Tan(3.0 * math.Pi / 16.0)}, 0.5, 0.5); edge.Radiance.X <= 0.0 || edge.Radiance.X >= 1.0 {
+		t.Fatalf("Spot light failure: expected the light to fade out between the angles but got %f", edge.Radiance.X)
+	}
+}
+
+func TestRectangleLightIsOneSided(t *testing.T) {
+	light := NewRectangleLight(Vec3{X: -0.5, Y: 1.0, Z: -0.5}, Vec3{X: 1.0}, Vec3{Z: 1.0}, white, 1.0, 4)
+
+	// The cross product of the edges points downwards, so only the area below the light is lit
+	if below := light.Sample(Vec3{}, 0.5, 0.5); below.Radiance.X <= 0.0 {
+		t.Fatalf("Rectangle light failure: expected the area below the light to be lit")
+	}
+
+	if above := light.Sample(Vec3{Y: 2.0}, 0.5, 0.5); above.Radiance.X != 0.0 {
+		t.Fatalf("Rectangle light failure: expected the area above the light to be dark but got %f", above.Radiance.X)
+	}
+}
+
+func TestSampleLight(t *testing.T) {
+	light := NewSphereLight(Vec3{X: 0.0, Y: 10.0, Z: 0.0}, 1.0, white, 100.0, 16)
+	samples := SampleLight(light, Vec3{})
+	total := Vec3{}
+
+	for _, sample := range samples {
+		total.Add(sample.Radiance)
+	}
+
+	if len(samples) != 16 {
+		t.Fatalf("Light sampling failure: expected 16 samples but got %d", len(samples))
+	}
+
+	// Far away from the light, the samples add up to roughly the radiance of a point light
+	if math.Abs(total.X-1.0) > 0.02 {
+		t.Fatalf("Light sampling failure: expected a total radiance of roughly 1.0 but got %f", total.X)
+	}
+}
+
+// Light that remembers where it was sampled
+type recordingLight struct {
+	count int
+	u, v  []float64
+}
+
+func (l *recordingLight) Sample(point Vec3, u float64, v float64) LightSample {
+	l.u = append(l.u, u)
+	l.v = append(l.v, v)
+	return LightSample{}
+}
+
+func (l *recordingLight) SampleCount() int {
+	return l.count
+}
+
+func (l *recordingLight) GetProperties() LightProperties {
+	return LightProperties{}
+}
+
+func TestSampleLightCoverage(t *testing.T) {
+	for _, count := range []int{2, 3, 5, 7, 16} {
+		light := recordingLight{count: count}
+		SampleLight(&light, Vec3{})
+
+		// The outermost samples sit equally far from opposite edges when the grid spans the whole light
+		for axis, values := range [][]float64{light.u, light.v} {
+			low, high := math.Inf(1), math.Inf(-1)
+			for _, value := range values {
+				low, high = math.Min(low, value), math.Max(high, value)
+			}
+
+			if len(values) != count || math.Abs(low+high-1.0) > tolerance {
+				t.Fatalf("Light sampling failure: expected %d samples spanning axis %d for a count of %d but got %v", count, axis, count, values)
+			}
+		}
+	}
+}
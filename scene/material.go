@@ -0,0 +1,42 @@
+package scene
+
+import . "github.com/tntmeijs/gengo/mathematics"
+
+// Index of a material in the material list of a scene
+type MaterialID int
+
+type materialSDF func(point Vec3) (float64, MaterialID)
+
+// Describes how the surface of an object interacts with light
+type Material struct {
+	Albedo            Vec3
+	Roughness         float64
+	Metallic          float64
+	Specular          float64
+	Emissive          Vec3
+	Reflectivity      float64
+	Transparency      float64
+	IndexOfRefraction float64
+}
+
+// Create a new opaque, non-emissive material with the following properties:
+//
+//	Albedo    : base color of the surface as a normalized Vec3
+//	Roughness : microscopic roughness of the surface between 0.0 (smooth) and 1.0 (rough)
+//	Metallic  : whether the surface is a dielectric (0.0) or a metal (1.0)
+//
+// The remaining properties are set to sensible defaults and can be changed afterwards.
+func NewMaterial(albedo Vec3, roughness float64, metallic float64) Material {
+	return Material{
+		Albedo:            albedo,
+		Roughness:         ClampBetween(roughness, 0.0, 1.0),
+		Metallic:          ClampBetween(metallic, 0.0, 1.0),
+		Specular:          0.5,
+		IndexOfRefraction: 1.5,
+	}
+}
+
+// Material used for surfaces that do not have a valid material assigned to them
+func DefaultMaterial() Material {
+	return NewMaterial(Vec3{X: 0.8, Y: 0.8, Z: 0.8}, 0.5, 0.0)
+}
@@ -0,0 +1,83 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Settings that control how ambient occlusion is calculated
+type AmbientOcclusionSettings struct {
+	StepCount         int
+	StepDistance      float64
+	Strength          float64
+	HemisphereSamples int
+}
+
+// Create new ambient occlusion settings with the following properties:
+//
+//	StepCount         : number of points sampled along each direction
+//	StepDistance      : distance between two consecutive points along a direction
+//	Strength          : how dark occluded areas become
+//	HemisphereSamples : number of additional directions sampled in the hemisphere around the normal
+func NewAmbientOcclusionSettings(stepCount int, stepDistance float64, strength float64, hemisphereSamples int) AmbientOcclusionSettings {
+	return AmbientOcclusionSettings{stepCount, stepDistance, strength, hemisphereSamples}
+}
+
+// Calculate how much of the ambient light reaches a surface point, 1.0 means not occluded at all and 0.0 means
+// fully occluded.
+//
+// Points are sampled at increasing distances away from the surface. Without any nearby geometry the scene SDF
+// at each point equals the distance to the surface, any difference means something else is closer and occludes
+// the point. Samples close to the surface are weighted more heavily than samples further away.
+//
+// Reference: https://iquilezles.org/articles/nvscene2008/rwwtt.pdf
+func (s *Scene) AmbientOcclusion(point Vec3, normal Vec3, settings AmbientOcclusionSettings) float64 {
+	occlusion := s.occlusionAlongDirection(point, normal, normal, settings)
+
+	if settings.HemisphereSamples > 0 {
+		tangent, bitangent := orthonormalBasis(normal)
+
+		for i := 0; i < settings.HemisphereSamples; i++ {
+			direction := hemisphereDirection(i, settings.HemisphereSamples, normal, tangent, bitangent)
+			occlusion += s.occlusionAlongDirection(point, normal, direction, settings)
+		}
+
+		occlusion /= float64(settings.HemisphereSamples + 1)
+	}
+
+	return ClampBetween(1.0-settings.Strength*occlusion, 0.0, 1.0)
+}
+
+// Accumulate the occlusion along a single direction. A point on a tilted direction is closer to the surface than
+// the distance travelled along it, the expected distance is scaled by the cosine between the direction and the normal.
+func (s *Scene) occlusionAlongDirection(point Vec3, normal Vec3, direction Vec3, settings AmbientOcclusionSettings) float64 {
+	occlusion := 0.0
+	weight := 1.0
+	cosine := Dot(direction, normal)
+
+	for i := 1; i <= settings.StepCount; i++ {
+		stepDistance := settings.StepDistance * float64(i)
+		expectedDistance := stepDistance * cosine
+		distance := s.sceneSDF(Add(point, MultiplyScalar(direction, stepDistance)))
+
+		occlusion += (expectedDistance - distance) * weight
+		weight *= 0.5
+	}
+
+	return math.Max(occlusion, 0.0)
+}
+
+// Deterministic direction in the hemisphere around the normal, the directions are spread out evenly using a
+// Fibonacci spiral and are biased towards the normal like a cosine-weighted distribution
+//
+// Reference: https://extremelearning.com.au/how-to-evenly-distribute-points-on-a-sphere-more-effectively-than-the-canonical-fibonacci-lattice/
+func hemisphereDirection(index int, count int, normal Vec3, tangent Vec3, bitangent Vec3) Vec3 {
+	goldenAngle := math.Pi * (3.0 - math.Sqrt(5.0))
+
+	radius := math.Sqrt((float64(index) + 0.5) / float64(count))
+	sin, cos := math.Sincos(goldenAngle * float64(index))
+	height := math.Sqrt(1.0 - radius*radius)
+
+	return Normalize(AddAll(MultiplyScalar(tangent, radius*cos), MultiplyScalar(bitangent, radius*sin), MultiplyScalar(normal, height)))
+}
@@ -0,0 +1,30 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+var testOcclusionSettings = NewAmbientOcclusionSettings(5, 0.1, 2.0, 8)
+
+func TestAmbientOcclusionOpenSurface(t *testing.T) {
+	scene := NewScene(func(point Vec3) float64 { return PlaneSDF(point, Vec3{Y: 1.0}, 0.0) })
+	occlusion := scene.AmbientOcclusion(Vec3{}, Vec3{Y: 1.0}, testOcclusionSettings)
+
+	if math.Abs(occlusion-1.0) > tolerance {
+		t.Fatalf("Ambient occlusion failure: expected an open plane to be unoccluded but got %f", occlusion)
+	}
+}
+
+func TestAmbientOcclusionCorner(t *testing.T) {
+	// Inside corner formed by a floor and a wall
+	scene := NewScene(func(point Vec3) float64 { return math.Min(point.Y, point.X) })
+	open := scene.AmbientOcclusion(Vec3{X: 10.0}, Vec3{Y: 1.0}, testOcclusionSettings)
+	corner := scene.AmbientOcclusion(Vec3{X: 0.05}, Vec3{Y: 1.0}, testOcclusionSettings)
+
+	if corner >= open {
+		t.Fatalf("Ambient occlusion failure: expected the corner (%f) to be darker than the open floor (%f)", corner, open)
+	}
+}
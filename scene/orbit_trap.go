@@ -0,0 +1,68 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+type orbitTrapFunction func(point Vec3) OrbitTrap
+
+// Data gathered while iterating a fractal, the orbit of a point is tested against a point, a plane, and an axis
+// that all pass through the trap point
+type OrbitTrap struct {
+	Point, PlaneNormal, AxisDirection                   Vec3
+	MinPointDistance, MinPlaneDistance, MinAxisDistance float64
+	Iterations                                          int
+	SmoothIteration                                     float64
+}
+
+// Create a new orbit trap with the following properties:
+//
+//	Point         : point the orbit is tested against, the plane and the axis pass through this point as well
+//	PlaneNormal   : normal of the plane the orbit is tested against
+//	AxisDirection : direction of the axis the orbit is tested against
+func NewOrbitTrap(point Vec3, planeNormal Vec3, axisDirection Vec3) OrbitTrap {
+	return OrbitTrap{
+		Point:            point,
+		PlaneNormal:      Normalize(planeNormal),
+		AxisDirection:    Normalize(axisDirection),
+		MinPointDistance: math.Inf(1),
+		MinPlaneDistance: math.Inf(1),
+		MinAxisDistance:  math.Inf(1),
+	}
+}
+
+// Test a single point of the orbit against the trap
+func (o *OrbitTrap) record(z Vec3) {
+	offset := Sub(z, o.Point)
+	alongAxis := MultiplyScalar(o.AxisDirection, Dot(offset, o.AxisDirection))
+
+	o.MinPointDistance = math.Min(o.MinPointDistance, offset.MagnitudeSqrt())
+	o.MinPlaneDistance = math.Min(o.MinPlaneDistance, math.Abs(Dot(offset, o.PlaneNormal)))
+	o.MinAxisDistance = math.Min(o.MinAxisDistance, Length(Sub(offset, alongAxis)))
+	o.Iterations++
+}
+
+// Store the smooth escape value once a polynomial escape-time fractal has finished iterating. Orbits that escaped get
+// a fractional iteration count based on how far they overshot the bailout, which removes the banding of plain
+// iteration counts.
+//
+// Reference: https://iquilezles.org/articles/msetsmooth/
+func (o *OrbitTrap) finish(radius float64, bailout float64, power float64) {
+	o.SmoothIteration = float64(o.Iterations)
+
+	if radius > bailout && radius > 1.0 {
+		o.SmoothIteration -= math.Log(math.Log(radius)/math.Log(math.Max(bailout, 2.0))) / math.Log(power)
+	}
+}
+
+// Store the smooth escape value once an iterated function system fractal has finished iterating. These fractals
+// scale their orbit linearly each iteration, so the overshoot of the bailout is measured in powers of the scale.
+func (o *OrbitTrap) finishLinear(radius float64, bailout float64, scale float64) {
+	o.SmoothIteration = float64(o.Iterations)
+
+	if radius > bailout && scale > 1.0 {
+		o.SmoothIteration -= math.Log(radius/bailout) / math.Log(scale)
+	}
+}
@@ -0,0 +1,149 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+	. "github.com/tntmeijs/gengo/utility"
+)
+
+func TestOrbitTrapJuliaOrbit(t *testing.T) {
+	// Without a constant the orbit of (0, 2, 0) squares to (-4, 0, 0) and then escapes at (16, 0, 0)
+	samples := []struct {
+		trap                                       OrbitTrap
+		pointDistance, planeDistance, axisDistance float64
+	}{
+		{NewOrbitTrap(Vec3{}, Vec3{Y: 1.0}, Vec3{Y: 1.0}), 4.0, 0.0, 4.0},
+		{NewOrbitTrap(Vec3{Y: 3.0}, Vec3{X: 2.0}, Vec3{X: 1.0}), 5.0, 4.0, 3.0},
+		{NewOrbitTrap(Vec3{X: 16.0}, Vec3{Z: 1.0}, Vec3{Z: 1.0}), 0.0, 0.0, 0.0},
+	}
+
+	for _, sample := range samples {
+		trap := sample.trap
+		QuaternionJuliaOrbitTrapSDF(Vec3{Y: 2.0}, Quaternion{}, 0.0, 12, 4.0, &trap)
+
+		if math.Abs(trap.MinPointDistance-sample.pointDistance) > tolerance ||
+			math.Abs(trap.MinPlaneDistance-sample.planeDistance) > tolerance ||
+			math.Abs(trap.MinAxisDistance-sample.axisDistance) > tolerance {
+			t.Fatalf("OrbitTrap failure: expected distances of %f, %f, and %f but got %f, %f, and %f", sample.pointDistance, sample.planeDistance, sample.axisDistance, trap.MinPointDistance, trap.MinPlaneDistance, trap.MinAxisDistance)
+		}
+
+		// The orbit overshoots the bailout by a factor of four, exactly one iteration of squaring
+		if trap.Iterations != 2 || math.Abs(trap.SmoothIteration-1.0) > tolerance {
+			t.Fatalf("OrbitTrap failure: expected 2 iterations and a smooth iteration of 1.0 but got %d and %f", trap.Iterations, trap.SmoothIteration)
+		}
+	}
+}
+
+func TestOrbitTrapLinearSmoothIteration(t *testing.T) {
+	// The orbit of (1.25, 1.25, 1.25) escapes in a single iteration at (1.5, 1.5, 1.5), just past the bailout
+	trap := NewOrbitTrap(Vec3{}, Vec3{Y: 1.0}, Vec3{Y: 1.0})
+	SierpinskiTetrahedronOrbitTrapSDF(Vec3{X: 1.25, Y: 1.25, Z: 1.25}, 8, 2.0, 2.5, &trap)
+	expected := 1.0 - math.Log(math.Sqrt(6.75)/2.5)/math.Log(2.0)
+
+	if trap.Iterations != 1 || math.Abs(trap.SmoothIteration-expected) > tolerance {
+		t.Fatalf("OrbitTrap failure: expected 1 iteration and a smooth iteration of %f but got %d and %f", expected, trap.Iterations, trap.SmoothIteration)
+	}
+
+	// A vertex of the tetrahedron never escapes and stays well below the bailout
+	trap = NewOrbitTrap(Vec3{}, Vec3{Y: 1.0}, Vec3{Y: 1.0})
+	SierpinskiTetrahedronOrbitTrapSDF(Vec3{X: 1.0, Y: 1.0, Z: 1.0}, 8, 2.0, 100.0, &trap)
+
+	if trap.Iterations != 8 || trap.SmoothIteration != 8.0 {
+		t.Fatalf("OrbitTrap failure: expected 8 iterations and a smooth iteration of 8.0 but got %d and %f", trap.Iterations, trap.SmoothIteration)
+	}
+}
+
+func TestOrbitTrapSDFMatchesSDF(t *testing.T) {
+	rotation := Mat3FromQuaternion(QuaternionFromAxisAngle(Vec3{Z: 1.0}, 0.3))
+	samples := []struct {
+		name    string
+		shape   sdf
+		trapped func(point Vec3, trap *OrbitTrap) float64
+	}{
+		{
+			"Mandelbulb",
+			func(p Vec3) float64 { return MandelbulbSDF(p, 8, 8, 2.0) },
+			func(p Vec3, trap *OrbitTrap) float64 { return MandelbulbOrbitTrapSDF(p, 8, 8, 2.0, trap) },
+		},
+		{
+			"Mandelbox",
+			func(p Vec3) float64 { return MandelboxSDF(p, 8, -1.5, 1.0, 0.5, 1.0, 100.0) },
+			func(p Vec3, trap *OrbitTrap) float64 {
+				return MandelboxOrbitTrapSDF(p, 8, -1.5, 1.0, 0.5, 1.0, 100.0, trap)
+			},
+		},
+		{
+			"MengerSponge",
+			func(p Vec3) float64 { return MengerSpongeSDF(p, 4, 100.0) },
+			func(p Vec3, trap *OrbitTrap) float64 { return MengerSpongeOrbitTrapSDF(p, 4, 100.0, trap) },
+		},
+		{
+			"SierpinskiTetrahedron",
+			func(p Vec3) float64 { return SierpinskiTetrahedronSDF(p, 8, 2.0, 100.0) },
+			func(p Vec3, trap *OrbitTrap) float64 {
+				return SierpinskiTetrahedronOrbitTrapSDF(p, 8, 2.0, 100.0, trap)
+			},
+		},
+		{
+			"KaleidoscopicIFS",
+			func(p Vec3) float64 {
+				return KaleidoscopicIFSSDF(p, 8, 2.0, Vec3{X: 1.0, Y: 1.0, Z: 1.0}, rotation, 100.0)
+			},
+			func(p Vec3, trap *OrbitTrap) float64 {
+				return KaleidoscopicIFSOrbitTrapSDF(p, 8, 2.0, Vec3{X: 1.0, Y: 1.0, Z: 1.0}, rotation, 100.0, trap)
+			},
+		},
+		{
+			"QuaternionJulia",
+			func(p Vec3) float64 { return QuaternionJuliaSDF(p, Quaternion{W: -0.2, X: 0.6}, 0.0, 12, 4.0) },
+			func(p Vec3, trap *OrbitTrap) float64 {
+				return QuaternionJuliaOrbitTrapSDF(p, Quaternion{W: -0.2, X: 0.6}, 0.0, 12, 4.0, trap)
+			},
+		},
+	}
+
+	point := Vec3{X: 0.3, Y: 0.7, Z: 0.9}
+	for _, sample := range samples {
+		trap := NewOrbitTrap(Vec3{}, Vec3{Y: 1.0}, Vec3{Y: 1.0})
+		distance := sample.trapped(point, &trap)
+
+		if distance != sample.shape(point) {
+			t.Fatalf("%s failure: expected the orbit trap to leave the distance of %f untouched but got %f", sample.name, sample.shape(point), distance)
+		}
+
+		if trap.Iterations == 0 || math.IsInf(trap.MinPointDistance, 1) {
+			t.Fatalf("%s failure: expected the orbit to be recorded in the trap", sample.name)
+		}
+	}
+}
+
+func TestOrbitTrapColoring(t *testing.T) {
+	gradient := NewGradient(NewColorStop(0.0, Color{Alpha: 255}), NewColorStop(1.0, Color{Red: 255, Green: 255, Blue: 255, Alpha: 255}))
+	trap := NewOrbitTrap(Vec3{}, Vec3{Y: 1.0}, Vec3{Y: 1.0})
+	QuaternionJuliaOrbitTrapSDF(Vec3{Y: 2.0}, Quaternion{}, 0.0, 12, 4.0, &trap)
+
+	samples := []struct {
+		channel       OrbitTrapChannel
+		scale, offset float64
+		repeat        bool
+		gray          float64
+	}{
+		{ChannelPointDistance, 0.125, 0.0, false, 0.5},
+		{ChannelPointDistance, 1.0, 0.0, false, 1.0},
+		{ChannelPlaneDistance, 1.0, 0.25, false, 0.25},
+		{ChannelAxisDistance, 0.1, 0.0, true, 0.4},
+		{ChannelIterationCount, 0.3, 0.0, true, 0.6},
+		{ChannelSmoothIteration, 1.0, -0.75, false, 0.25},
+	}
+
+	for _, sample := range samples {
+		coloring := NewOrbitTrapColoring(sample.channel, gradient, sample.scale, sample.offset, sample.repeat)
+		albedo := coloring.Albedo(trap)
+
+		if math.Abs(albedo.X-sample.gray) > tolerance || albedo.X != albedo.Y || albedo.Y != albedo.Z {
+			t.Fatalf("OrbitTrapColoring failure: expected a gray of %f for channel %d but got %v", sample.gray, sample.channel, albedo)
+		}
+	}
+}
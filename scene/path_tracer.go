@@ -0,0 +1,246 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Settings that control how paths are traced through a scene
+type PathTracerSettings struct {
+	SamplesPerPixel      int
+	MaxDepth             int
+	RussianRouletteDepth int
+	Bias                 float64
+}
+
+// Monte Carlo path tracer that calculates global illumination by following random paths of light through the
+// scene. Every bounce samples the Cook-Torrance BRDF of the surface and gathers direct light from all light
+// sources in the scene, reflective and transparent materials reflect or refract the path instead.
+//
+// Reference: https://pbr-book.org/3ed-2018/Light_Transport_I_Surface_Reflection/Path_Tracing
+type PathTracer struct {
+	settings       PathTracerSettings
+	traceSettings  SphereTraceSettings
+	shadowSettings ShadowSettings
+}
+
+// Create new path tracer settings with the following properties:
+//
+//	SamplesPerPixel      : number of paths traced through each pixel, the results are averaged
+//	MaxDepth             : maximum number of surfaces a path bounces off before it is terminated
+//	RussianRouletteDepth : number of bounces after which paths carrying little light are randomly terminated
+//	Bias                 : distance a bounced ray starts away from the surface to prevent it from hitting its own surface
+func NewPathTracerSettings(samplesPerPixel int, maxDepth int, russianRouletteDepth int, bias float64) PathTracerSettings {
+	return PathTracerSettings{int(math.Max(float64(samplesPerPixel), 1.0)), maxDepth, russianRouletteDepth, bias}
+}
+
+// Create a new path tracer, rays are sphere traced through the scene and shadow rays use the shadow settings
+func NewPathTracer(settings PathTracerSettings, traceSettings SphereTraceSettings, shadowSettings ShadowSettings) *PathTracer {
+	return &PathTracer{settings, traceSettings, shadowSettings}
+}
+
+// Calculate the linear color of a pixel by averaging the paths traced through random points within the pixel.
+// The random numbers only depend on the pixel coordinates, which makes each render reproducible.
+func (p *PathTracer) RenderPixel(scene *Scene, camera *Camera, pixelX int, pixelY int, resolutionX int, resolutionY int) Vec3 {
+	random := NewRandomFromValues(pixelX, pixelY)
+	color := Vec3{}
+
+	for i := 0; i < p.settings.SamplesPerPixel; i++ {
+		ray := camera.GenerateRayForPixelWithOffset(pixelX, pixelY, random.Float64(), random.Float64(), resolutionX, resolutionY)
+		color.Add(p.Radiance(scene, ray, camera.GetFarPlane(), &random))
+	}
+
+	return MultiplyScalar(color, 1.0/float64(p.settings.SamplesPerPixel))
+}
+
+// Estimate the light travelling back along a ray by following a single random path through the scene
+func (p *PathTracer) Radiance(scene *Scene, ray Ray, maxDistance float64, random *Random) Vec3 {
+	radiance := Vec3{}
+	throughput := Vec3{X: 1.0, Y: 1.0, Z: 1.0}
+
+	for depth := 0; depth < p.settings.MaxDepth; depth++ {
+		didHit, hitInfo := scene.SphereTrace(ray, maxDistance, p.traceSettings)
+
+		// Fog along the path adds its own light and dims all light gathered further along the path, paths that
+		// missed pass through the fog all the way up to the maximum distance
+		fogDistance := maxDistance
+		if didHit {
+			fogDistance = hitInfo.RayLength
+		}
+
+		fogTransmittance, fogInScattered := scene.EvaluateFog(ray, fogDistance)
+		radiance.Add(Multiply(throughput, fogInScattered))
+		throughput = Multiply(throughput, fogTransmittance)
+
+		if !didHit {
+			radiance.Add(Multiply(throughput, scene.GetBackground(ray.Direction)))
+			break
+		}
+
+		material := scene.GetSurfaceMaterial(hitInfo)
+		normal := hitInfo.Normal
+		viewDirection := Negate(Normalize(ray.Direction))
+
+		// Emissive surfaces are not part of the light list, so their light is only found by hitting them
+		radiance.Add(Multiply(throughput, material.Emissive))
+
+		// Mirror and glass surfaces scatter light in a single direction, the event is picked at random with the
+		// probability of the fraction of light it accounts for
+		event := random.Float64()
+		direction := Normalize(ray.Direction)
+
+		if event < material.Reflectivity {
+			throughput = Multiply(throughput, reflectionTint(material))
+			ray = reflectionRay(hitInfo, direction, p.settings.Bias)
+			continue
+		}
+
+		if event < material.Reflectivity+material.Transparency {
+			if random.Float64() < schlickReflectance(Dot(viewDirection, normal), material.IndexOfRefraction) {
+				ray = reflectionRay(hitInfo, direction, p.settings.Bias)
+				continue
+			}
+
+			transmitted, transmittance, ok := scene.transmit(hitInfo, direction, material, p.traceSettings, p.settings.Bias, maxDistance)
+			if !ok {
+				break
+			}
+
+			throughput = Multiply(throughput, transmittance)
+			ray = transmitted
+			continue
+		}
+
+		// Next event estimation, sample every light directly instead of hoping a bounce happens to find it
+		radiance.Add(Multiply(throughput, p.directLight(scene, hitInfo.Point, normal, viewDirection, material, random)))
+
+		bounce, weight, ok := sampleBRDF(material, normal, viewDirection, random)
+		if !ok {
+			break
+		}
+
+		throughput = Multiply(throughput, weight)
+
+		// Russian roulette, randomly terminate paths that carry little light and boost the survivors to
+		// compensate so the estimate stays unbiased
+		if depth >= p.settings.RussianRouletteDepth {
+			survival := ClampBetween(math.Max(throughput.X, math.Max(throughput.Y, throughput.Z)), 0.05, 0.95)
+			if random.Float64() >= survival {
+				break
+			}
+
+			throughput.MultiplyWith(1.0 / survival)
+		}
+
+		ray = Ray{Origin: Add(hitInfo.Point, MultiplyScalar(normal, p.settings.Bias)), Direction: bounce}
+	}
+
+	return radiance
+}
+
+// Gather the light arriving at a surface point directly from the lights in the scene, area lights are sampled
+// at a single random point
+func (p *PathTracer) directLight(scene *Scene, point Vec3, normal Vec3, viewDirection Vec3, material Material, random *Random) Vec3 {
+	light := Vec3{}
+
+	for _, source := range scene.GetLights() {
+		// Paths that leave the scene already gather the light of the environment, sampling it here as well would
+		// count its light twice
+		if _, ok := source.(*EnvironmentLight); ok {
+			continue
+		}
+
+		sample := source.Sample(point, random.Float64(), random.Float64())
+		normalDotLight := Dot(normal, sample.Direction)
+
+		if normalDotLight <= 0.0 {
+			continue
+		}
+
+		shadow := scene.ShadowFactor(point, normal, sample, source.GetProperties().ShadowSoftness, p.shadowSettings)
+		if shadow <= 0.0 {
+			continue
+		}
+
+		brdf := cookTorranceBRDF(material, normal, viewDirection, sample.Direction)
+		light.Add(Multiply(brdf, MultiplyScalar(sample.Radiance, normalDotLight*shadow)))
+	}
+
+	return light
+}
+
+// Pick a random bounce direction by importance sampling either the diffuse or the specular lobe of the
+// Cook-Torrance BRDF. The weight is the BRDF multiplied by the cosine term and divided by the probability of
+// picking the direction, false is returned when the bounce ends up below the surface.
+func sampleBRDF(material Material, normal Vec3, viewDirection Vec3, random *Random) (Vec3, Vec3, bool) {
+	roughness := math.Max(material.Roughness, 0.02)
+	normalDotView := math.Max(Dot(normal, viewDirection), 1e-4)
+	tangent, bitangent := orthonormalBasis(normal)
+
+	// Surfaces that reflect more light specularly sample the specular lobe more often
+	fresnel := fresnelSchlick(normalDotView, baseReflectance(material))
+	specularProbability := (fresnel.X + fresnel.Y + fresnel.Z) / 3.0
+	if material.Metallic < 1.0 {
+		specularProbability = ClampBetween(specularProbability, 0.1, 0.9)
+	} else {
+		specularProbability = 1.0
+	}
+
+	u, v := random.Float64(), random.Float64()
+	var direction Vec3
+
+	if random.Float64() < specularProbability {
+		halfway := toWorld(sampleGGXHalfway(u, v, roughness), tangent, bitangent, normal)
+		direction = Reflect(Negate(viewDirection), halfway)
+	} else {
+		direction = toWorld(sampleCosineHemisphere(u, v), tangent, bitangent, normal)
+	}
+
+	normalDotLight := Dot(normal, direction)
+	if normalDotLight <= 0.0 {
+		return Vec3{}, Vec3{}, false
+	}
+
+	// The direction could have been picked by either lobe, so the probability is the mix of both
+	halfway := Normalize(Add(direction, viewDirection))
+	normalDotHalfway := math.Max(Dot(normal, halfway), 0.0)
+	viewDotHalfway := math.Max(Dot(viewDirection, halfway), 1e-4)
+
+	specularPdf := distributionGGX(normalDotHalfway, roughness) * normalDotHalfway / (4.0 * viewDotHalfway)
+	diffusePdf := normalDotLight / math.Pi
+	pdf := specularProbability*specularPdf + (1.0-specularProbability)*diffusePdf
+
+	if pdf <= 0.0 {
+		return Vec3{}, Vec3{}, false
+	}
+
+	brdf := cookTorranceBRDF(material, normal, viewDirection, direction)
+	return direction, MultiplyScalar(brdf, normalDotLight/pdf), true
+}
+
+// Sample a direction on the hemisphere around the Z axis with a probability proportional to the cosine of the
+// angle with the Z axis
+func sampleCosineHemisphere(u float64, v float64) Vec3 {
+	radius := math.Sqrt(u)
+	sin, cos := math.Sincos(2.0 * math.Pi * v)
+
+	return Vec3{X: radius * cos, Y: radius * sin, Z: math.Sqrt(math.Max(1.0-u, 0.0))}
+}
+
+// Sample a microfacet normal around the Z axis with a probability proportional to the GGX normal distribution
+//
+// Reference: https://agraphicsguynotes.com/posts/sample_microfacet_brdf/
+func sampleGGXHalfway(u float64, v float64, roughness float64) Vec3 {
+	alpha := roughness * roughness
+	cosTheta := math.Sqrt((1.0 - u) / (1.0 + (alpha*alpha-1.0)*u))
+	sinTheta := math.Sqrt(math.Max(1.0-cosTheta*cosTheta, 0.0))
+	sin, cos := math.Sincos(2.0 * math.Pi * v)
+
+	return Vec3{X: sinTheta * cos, Y: sinTheta * sin, Z: cosTheta}
+}
+
+// Move a direction from the space around the Z axis into the space around the normal
+func toWorld(direction Vec3, tangent Vec3, bitangent Vec3, normal Vec3) Vec3 {
+	return AddAll(MultiplyScalar(tangent, direction.X), MultiplyScalar(bitangent, direction.Y), MultiplyScalar(normal, direction.Z))
+}
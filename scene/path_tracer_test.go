@@ -0,0 +1,77 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+var testTraceSettings = NewSphereTraceSettings(1.0, 0.0001, 256)
+
+func testPathTracer(maxDepth int) *PathTracer {
+	return NewPathTracer(NewPathTracerSettings(1, maxDepth, maxDepth, 0.001), testTraceSettings, testShadowSettings)
+}
+
+func TestPathTracerMissReturnsBackground(t *testing.T) {
+	scene := NewScene(unitSphere)
+	random := NewRandom(0)
+	background := Vec3{X: 0.25, Y: 0.5, Z: 0.75}
+	ray := Ray{Origin: Vec3{Z: -5.0}, Direction: Vec3{Y: 1.0}}
+	scene.SetEnvironment(NewSolidEnvironment(background))
+
+	if result := testPathTracer(4).Radiance(&scene, ray, 100.0, &random); result != background {
+		t.Fatalf("Path tracer failure: expected the background %v but got %v", background, result)
+	}
+}
+
+func TestPathTracerEmissiveSurface(t *testing.T) {
+	material := NewMaterial(Vec3{}, 1.0, 0.0)
+	material.Emissive = Vec3{X: 2.0, Y: 2.0, Z: 2.0}
+	scene := NewSceneFromGraph(NewSphereNode(1.0), material)
+	random := NewRandom(0)
+	ray := Ray{Origin: Vec3{Z: -5.0}, Direction: Vec3{Z: 1.0}}
+
+	// A black surface without lights only contributes its own emission
+	if result := testPathTracer(1).Radiance(&scene, ray, 100.0, &random); result != material.Emissive {
+		t.Fatalf("Path tracer failure: expected %v but got %v", material.Emissive, result)
+	}
+}
+
+func TestPathTracerIsDeterministic(t *testing.T) {
+	scene := shadowTestScene()
+	scene.AddLight(NewDirectionalLight(Vec3{X: 1.0, Y: -1.0, Z: 0.5}, white, 1.0))
+	scene.SetEnvironment(NewSolidEnvironment(Vec3{X: 0.5, Y: 0.5, Z: 0.5}))
+	camera := NewCamera(Vec3{Y: 2.0, Z: -6.0}, Vec3{Y: 1.0}, 0.001, 50.0)
+	tracer := testPathTracer(4)
+
+	a := tracer.RenderPixel(&scene, &camera, 8, 8, 16, 16)
+	b := tracer.RenderPixel(&scene, &camera, 8, 8, 16, 16)
+
+	if a != b {
+		t.Fatalf("Path tracer failure: rendering the same pixel twice resulted in %v and %v", a, b)
+	}
+}
+
+func TestSampleBRDFConservesEnergy(t *testing.T) {
+	normal := Vec3{Y: 1.0}
+	viewDirection := Normalize(Vec3{X: 0.3, Y: 1.0})
+	random := NewRandom(7)
+	sampleCount := 20000
+
+	for _, material := range []Material{NewMaterial(white, 1.0, 0.0), NewMaterial(white, 0.3, 1.0)} {
+		total := 0.0
+
+		for i := 0; i < sampleCount; i++ {
+			if _, weight, ok := sampleBRDF(material, normal, viewDirection, &random); ok {
+				total += weight.X
+			}
+		}
+
+		// A white surface reflects at most all of the light arriving at it, some energy is lost to the Fresnel
+		// term of the diffuse lobe and to the geometry term of the specular lobe
+		if average := total / float64(sampleCount); average > 1.02 || average < 0.75 || math.IsNaN(average) {
+			t.Fatalf("BRDF sampling failure: expected an average weight close to 1.0 but got %f", average)
+		}
+	}
+}
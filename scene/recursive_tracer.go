@@ -0,0 +1,89 @@
+package scene
+
+import . "github.com/tntmeijs/gengo/mathematics"
+
+// Calculates the local color of a surface point without any reflections or refractions
+type surfaceShadingFunction func(hitInfo SurfaceHitInfo, viewDirection Vec3) Vec3
+
+// Settings that control how reflection and refraction rays are traced through a scene
+type RecursiveTracerSettings struct {
+	MaxDepth int
+	Bias     float64
+}
+
+// Traces reflection and refraction rays recursively (Whitted-style ray tracing). The reflectivity and transparency
+// of a material determine how much of its color comes from the reflected and refracted rays, the rest of the color
+// comes from the surface shading function.
+type RecursiveTracer struct {
+	settings      RecursiveTracerSettings
+	traceSettings SphereTraceSettings
+	shade         surfaceShadingFunction
+}
+
+// Create new recursive tracer settings with the following properties:
+//
+//	MaxDepth : maximum number of times a ray is reflected or refracted
+//	Bias     : distance a new ray starts away from the surface to prevent it from hitting its own surface
+func NewRecursiveTracerSettings(maxDepth int, bias float64) RecursiveTracerSettings {
+	return RecursiveTracerSettings{maxDepth, bias}
+}
+
+// Create a new recursive tracer, rays are sphere traced through the scene and every surface they hit is shaded
+// using the shading function
+func NewRecursiveTracer(settings RecursiveTracerSettings, traceSettings SphereTraceSettings, shade surfaceShadingFunction) *RecursiveTracer {
+	return &RecursiveTracer{settings, traceSettings, shade}
+}
+
+// Calculate the linear color of the light travelling back along a ray, including the fog in the scene
+func (r *RecursiveTracer) Trace(scene *Scene, ray Ray, maxDistance float64) Vec3 {
+	return r.trace(scene, ray, maxDistance, 0)
+}
+
+// Add the reflections and refractions to the local color of a surface a primary ray hit, the fog along the
+// primary ray itself is not applied
+func (r *RecursiveTracer) ShadeSurface(scene *Scene, hitInfo SurfaceHitInfo, ray Ray, localColor Vec3, maxDistance float64) Vec3 {
+	return r.combine(scene, hitInfo, Normalize(ray.Direction), localColor, maxDistance, 0)
+}
+
+// Trace a ray at the specified recursion depth
+func (r *RecursiveTracer) trace(scene *Scene, ray Ray, maxDistance float64, depth int) Vec3 {
+	didHit, hitInfo := scene.SphereTrace(ray, maxDistance, r.traceSettings)
+	if !didHit {
+		return scene.ApplyFog(ray, maxDistance, scene.GetBackground(ray.Direction))
+	}
+
+	direction := Normalize(ray.Direction)
+	localColor := r.shade(hitInfo, Negate(direction))
+
+	return scene.ApplyFog(ray, hitInfo.RayLength, r.combine(scene, hitInfo, direction, localColor, maxDistance, depth))
+}
+
+// Blend the local color of a surface with the light arriving along its reflection and refraction rays
+func (r *RecursiveTracer) combine(scene *Scene, hitInfo SurfaceHitInfo, direction Vec3, localColor Vec3, maxDistance float64, depth int) Vec3 {
+	material := scene.GetSurfaceMaterial(hitInfo)
+	reflectivity := ClampBetween(material.Reflectivity, 0.0, 1.0)
+	transparency := ClampBetween(material.Transparency, 0.0, 1.0-reflectivity)
+
+	if depth >= r.settings.MaxDepth || (reflectivity <= 0.0 && transparency <= 0.0) {
+		return localColor
+	}
+
+	color := MultiplyScalar(localColor, 1.0-reflectivity-transparency)
+	reflected := r.trace(scene, reflectionRay(hitInfo, direction, r.settings.Bias), maxDistance, depth+1)
+	color.Add(MultiplyScalar(Multiply(reflected, reflectionTint(material)), reflectivity))
+
+	if transparency > 0.0 {
+		// Transparent surfaces reflect more light at grazing angles
+		fresnel := schlickReflectance(-Dot(direction, hitInfo.Normal), material.IndexOfRefraction)
+		refracted := reflected
+
+		if ray, transmittance, ok := scene.transmit(hitInfo, direction, material, r.traceSettings, r.settings.Bias, maxDistance); ok {
+			refracted = Multiply(r.trace(scene, ray, maxDistance, depth+1), transmittance)
+		}
+
+		color.Add(MultiplyScalar(reflected, transparency*fresnel))
+		color.Add(MultiplyScalar(refracted, transparency*(1.0-fresnel)))
+	}
+
+	return color
+}
@@ -0,0 +1,89 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+var red = Vec3{X: 1.0}
+var green = Vec3{Y: 1.0}
+
+// Unit sphere at the origin using the first material in front of a wall using the second material
+func recursiveTestScene(sphere Material) Scene {
+	wall := NewPrimitiveNode(func(point Vec3) float64 { return PlaneSDF(point, Vec3{Z: -1.0}, 5.0) })
+	return NewSceneFromGraph(NewUnionNode(NewMaterialNode(NewSphereNode(1.0), 0), NewMaterialNode(wall, 1)), sphere, DefaultMaterial())
+}
+
+func nearlyEqual(a Vec3, b Vec3) bool {
+	return Length(Sub(a, b)) < 0.001
+}
+
+// Shade the wall green and everything else red
+func wallShader(hitInfo SurfaceHitInfo, viewDirection Vec3) Vec3 {
+	if hitInfo.Point.Z > 4.0 {
+		return green
+	}
+
+	return red
+}
+
+func TestSphereTraceInside(t *testing.T) {
+	scene := NewScene(unitSphere)
+	didExit, hitInfo := scene.SphereTraceInside(Ray{Direction: Vec3{X: 1.0}}, 10.0, testTraceSettings)
+
+	if !didExit || math.Abs(hitInfo.RayLength-1.0) > 0.001 || !nearlyEqual(hitInfo.Normal, Vec3{X: 1.0}) {
+		t.Fatalf("Sphere trace failure: expected to leave the sphere at {1 0 0} but got %v", hitInfo)
+	}
+}
+
+func TestSchlickReflectance(t *testing.T) {
+	if reflectance := schlickReflectance(1.0, 1.5); math.Abs(reflectance-0.04) > tolerance {
+		t.Fatalf("Fresnel failure: expected glass to reflect 4%% of the light head-on but got %f", reflectance)
+	}
+
+	if reflectance := schlickReflectance(0.0, 1.5); reflectance != 1.0 {
+		t.Fatalf("Fresnel failure: expected all light to be reflected at a grazing angle but got %f", reflectance)
+	}
+}
+
+func TestRecursiveTracerMirror(t *testing.T) {
+	mirror := NewMaterial(white, 0.0, 0.0)
+	mirror.Reflectivity = 1.0
+	scene := recursiveTestScene(mirror)
+	scene.SetEnvironment(NewSolidEnvironment(Vec3{Z: 1.0}))
+	tracer := NewRecursiveTracer(NewRecursiveTracerSettings(4, 0.002), testTraceSettings, wallShader)
+
+	// The mirror reflects the ray straight back into the background
+	if result := tracer.Trace(&scene, Ray{Origin: Vec3{Z: -3.0}, Direction: Vec3{Z: 1.0}}, 100.0); !nearlyEqual(result, Vec3{Z: 1.0}) {
+		t.Fatalf("Reflection failure: expected the background but got %v", result)
+	}
+}
+
+func TestRecursiveTracerGlass(t *testing.T) {
+	glass := NewMaterial(white, 0.0, 0.0)
+	glass.Transparency = 1.0
+	scene := recursiveTestScene(glass)
+	tracer := NewRecursiveTracer(NewRecursiveTracerSettings(4, 0.002), testTraceSettings, wallShader)
+
+	// Looking through the center of the sphere the wall is visible, apart from the light that is reflected
+	result := tracer.Trace(&scene, Ray{Origin: Vec3{Z: -3.0}, Direction: Vec3{Z: 1.0}}, 100.0)
+	if result.X != 0.0 || math.Abs(result.Y-0.96) > 0.001 {
+		t.Fatalf("Refraction failure: expected {0 0.96 0} but got %v", result)
+	}
+
+	// Tinted glass transmits its albedo over every unit of distance, the ray travels through two units of glass
+	tinted := NewMaterial(Vec3{X: 0.5, Y: 0.5, Z: 0.5}, 0.0, 0.0)
+	tinted.Transparency = 1.0
+	tintedScene := recursiveTestScene(tinted)
+	if result := tracer.Trace(&tintedScene, Ray{Origin: Vec3{Z: -3.0}, Direction: Vec3{Z: 1.0}}, 100.0); math.Abs(result.Y-0.96*0.25) > 0.001 {
+		t.Fatalf("Refraction failure: expected the wall to be dimmed to %f but got %v", 0.96*0.25, result)
+	}
+
+	// Without recursion the local color is used
+	shallow := NewRecursiveTracer(NewRecursiveTracerSettings(0, 0.002), testTraceSettings, wallShader)
+	if result := shallow.Trace(&scene, Ray{Origin: Vec3{Z: -3.0}, Direction: Vec3{Z: 1.0}}, 100.0); result != red {
+		t.Fatalf("Refraction failure: expected the local color but got %v", result)
+	}
+}
@@ -0,0 +1,73 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Maximum number of times a ray reflects off the inside of a shape before it is considered trapped
+const maxInternalReflections = 8
+
+// Fraction of light a transparent surface reflects according to the Schlick approximation, the cosine is taken
+// between the incoming ray and the normal on the side of the surface the ray arrives from
+func schlickReflectance(cosTheta float64, indexOfRefraction float64) float64 {
+	r0 := (1.0 - indexOfRefraction) / (1.0 + indexOfRefraction)
+	r0 *= r0
+
+	return r0 + (1.0-r0)*math.Pow(1.0-ClampBetween(cosTheta, 0.0, 1.0), 5.0)
+}
+
+// Color of a surface's reflections, metals tint their reflections with their albedo
+func reflectionTint(material Material) Vec3 {
+	white := Vec3{X: 1.0, Y: 1.0, Z: 1.0}
+	return Add(MultiplyScalar(white, 1.0-material.Metallic), MultiplyScalar(material.Albedo, material.Metallic))
+}
+
+// Ray that reflects off the surface that was hit
+func reflectionRay(hitInfo SurfaceHitInfo, direction Vec3, bias float64) Ray {
+	return Ray{Origin: Add(hitInfo.Point, MultiplyScalar(hitInfo.Normal, bias)), Direction: Reflect(direction, hitInfo.Normal)}
+}
+
+// Follow a ray that enters a transparent shape through the interior of the shape until it leaves it again. The ray
+// bends according to Snell's law when entering and leaving the shape, and reflects off the inside of the surface
+// when it reaches it at too shallow an angle to leave (total internal reflection).
+//
+// The returned ray starts where the light leaves the shape, the transmittance is the fraction of light that is
+// not absorbed along the way. Light is absorbed by the interior using Beer's law, where the albedo is the color
+// the interior transmits over a unit distance. False is returned when the ray never leaves the shape.
+func (s *Scene) transmit(hitInfo SurfaceHitInfo, direction Vec3, material Material, settings SphereTraceSettings, bias float64, maxDistance float64) (Ray, Vec3, bool) {
+	indexOfRefraction := math.Max(material.IndexOfRefraction, epsilon)
+	refracted, ok := Refract(Normalize(direction), hitInfo.Normal, 1.0/indexOfRefraction)
+	if !ok {
+		return Ray{}, Vec3{}, false
+	}
+
+	ray := Ray{Origin: Sub(hitInfo.Point, MultiplyScalar(hitInfo.Normal, bias)), Direction: refracted}
+	transmittance := Vec3{X: 1.0, Y: 1.0, Z: 1.0}
+
+	for i := 0; i <= maxInternalReflections; i++ {
+		didExit, exitInfo := s.SphereTraceInside(ray, maxDistance, settings)
+		if !didExit {
+			return Ray{}, Vec3{}, false
+		}
+
+		// Beer's law, exp(ln(albedo) * distance), which equals the albedo after a unit distance
+		transmittance = Multiply(transmittance, Vec3{
+			X: math.Pow(math.Max(material.Albedo.X, 0.0), exitInfo.RayLength),
+			Y: math.Pow(math.Max(material.Albedo.Y, 0.0), exitInfo.RayLength),
+			Z: math.Pow(math.Max(material.Albedo.Z, 0.0), exitInfo.RayLength),
+		})
+
+		// The normal points out of the shape, so it has to be flipped to point against the ray
+		inwardNormal := Negate(exitInfo.Normal)
+
+		if leaving, ok := Refract(ray.Direction, inwardNormal, indexOfRefraction); ok {
+			return Ray{Origin: Add(exitInfo.Point, MultiplyScalar(exitInfo.Normal, bias)), Direction: leaving}, transmittance, true
+		}
+
+		ray = Ray{Origin: Add(exitInfo.Point, MultiplyScalar(inwardNormal, bias)), Direction: Reflect(ray.Direction, inwardNormal)}
+	}
+
+	return Ray{}, Vec3{}, false
+}
@@ -6,20 +6,134 @@ const epsilon = 0.001
 
 type sdf func(point Vec3) float64
 
+// Reason a ray march came to a halt
+type MarchTermination int
+
+const (
+	// The ray reached the surface of the scene
+	TerminationSurfaceHit MarchTermination = iota
+
+	// The ray travelled beyond the maximum distance without reaching a surface
+	TerminationMaxDistance
+
+	// The ray ran out of iterations before it reached a surface or the maximum distance
+	TerminationMaxIterations
+)
+
 // Information about the surface a ray hit
 type SurfaceHitInfo struct {
-	Point, Normal Vec3
-	RayLength     float64
+	Point, Normal       Vec3
+	RayLength           float64
+	TerminationDistance float64
+	StepCount           int
+	Termination         MarchTermination
+	MaterialID          MaterialID
+	HasOrbitTrap        bool
+	OrbitTrap           OrbitTrap
 }
 
 // Represents a scene that can be rendered
 type Scene struct {
-	sceneSDF sdf
+	sceneSDF    sdf
+	materialSDF materialSDF
+	materials   []Material
+	lights      []Light
+	orbitTrap   orbitTrapFunction
+	coloring    *OrbitTrapColoring
+	fog         []Fog
+	environment Environment
+}
+
+// Human-readable name of the termination reason
+func (t MarchTermination) String() string {
+	switch t {
+	case TerminationSurfaceHit:
+		return "surface hit"
+	case TerminationMaxDistance:
+		return "max distance"
+	case TerminationMaxIterations:
+		return "max iterations"
+	default:
+		return "unknown"
+	}
 }
 
 // Create a new scene
 func NewScene(sceneSDF sdf) Scene {
-	return Scene{sceneSDF}
+	return Scene{sceneSDF: sceneSDF}
+}
+
+// Create a new scene from a signed distance function that reports the material of the closest surface as well,
+// the material IDs are indices into the list of materials
+func NewMaterialScene(sceneSDF materialSDF, materials []Material) Scene {
+	distanceOnly := func(point Vec3) float64 {
+		distance, _ := sceneSDF(point)
+		return distance
+	}
+
+	return Scene{sceneSDF: distanceOnly, materialSDF: sceneSDF, materials: materials}
+}
+
+// Get the material with the specified ID, the default material is returned for unknown IDs
+func (s *Scene) GetMaterial(id MaterialID) Material {
+	if id < 0 || int(id) >= len(s.materials) {
+		return DefaultMaterial()
+	}
+
+	return s.materials[id]
+}
+
+// Add a light source to the scene
+func (s *Scene) AddLight(light Light) {
+	s.lights = append(s.lights, light)
+}
+
+// Get all light sources in the scene
+func (s *Scene) GetLights() []Light {
+	return s.lights
+}
+
+// Set the function that gathers orbit trap data at the points where rays hit the surface, this is usually the
+// orbit trap variant of the fractal the scene consists of
+func (s *Scene) SetOrbitTrapFunction(trap orbitTrapFunction) {
+	s.orbitTrap = trap
+}
+
+// Set the coloring that turns the orbit trap data of a hit into the albedo of the surface
+func (s *Scene) SetOrbitTrapColoring(coloring OrbitTrapColoring) {
+	s.coloring = &coloring
+}
+
+// Get the material of the surface a ray hit, the albedo is replaced by the orbit trap coloring when the hit
+// carries orbit trap data
+func (s *Scene) GetSurfaceMaterial(hitInfo SurfaceHitInfo) Material {
+	material := s.GetMaterial(hitInfo.MaterialID)
+
+	if hitInfo.HasOrbitTrap && s.coloring != nil {
+		material.Albedo = s.coloring.Albedo(hitInfo.OrbitTrap)
+	}
+
+	return material
+}
+
+// Set the environment that surrounds the scene
+func (s *Scene) SetEnvironment(environment Environment) {
+	s.environment = environment
+}
+
+// Get the color seen by a ray that leaves the scene in the specified direction, this is black when the scene
+// does not have an environment
+func (s *Scene) GetBackground(direction Vec3) Vec3 {
+	if s.environment == nil {
+		return Vec3{}
+	}
+
+	return s.environment.Radiance(Normalize(direction))
+}
+
+// Evaluate the signed distance from a point in space to the scene's surface
+func (s *Scene) GetDistance(point Vec3) float64 {
+	return s.sceneSDF(point)
 }
 
 // Check whether the point in space intersects with the scene's surface
@@ -29,7 +143,18 @@ func (s *Scene) DoesPointIntersectSurface(point Vec3) bool {
 
 // Calculate the information at the position a point intersects the scene's surface
 func (s *Scene) GetIntersectionPointSurfaceHitInfo(point Vec3, rayLength float64) SurfaceHitInfo {
-	return SurfaceHitInfo{point, s.approximateNormal(point), rayLength}
+	hitInfo := SurfaceHitInfo{Point: point, Normal: s.approximateNormal(point), RayLength: rayLength, Termination: TerminationSurfaceHit}
+
+	if s.materialSDF != nil {
+		_, hitInfo.MaterialID = s.materialSDF(point)
+	}
+
+	if s.orbitTrap != nil {
+		hitInfo.HasOrbitTrap = true
+		hitInfo.OrbitTrap = s.orbitTrap(point)
+	}
+
+	return hitInfo
 }
 
 // Approximate the surface normal by samping points around the intersection point
@@ -11,10 +11,178 @@ func SphereSDF(point Vec3, radius float64) float64 {
 	return math.Sqrt((point.X*point.X)+(point.Y*point.Y)+(point.Z*point.Z)) - radius
 }
 
+// Axis-aligned box centered on the origin
+//
+// Reference: https://iquilezles.org/articles/distfunctions/
+func BoxSDF(point Vec3, halfExtents Vec3) float64 {
+	q := Sub(Abs(point), halfExtents)
+	outside := Length(ComponentMax(q, Vec3{}))
+	inside := math.Min(math.Max(q.X, math.Max(q.Y, q.Z)), 0.0)
+
+	return outside + inside
+}
+
+// Axis-aligned box centered on the origin with edges rounded off by the radius, the rounding happens inside
+// of the half extents so the box never grows beyond them
+func RoundedBoxSDF(point Vec3, halfExtents Vec3, radius float64) float64 {
+	shrunk := Sub(halfExtents, Vec3{X: radius, Y: radius, Z: radius})
+	return BoxSDF(point, shrunk) - radius
+}
+
+// Torus centered on the origin lying in the XZ plane
+//
+//	MajorRadius : distance from the center of the torus to the center of the tube
+//	MinorRadius : radius of the tube
+func TorusSDF(point Vec3, majorRadius float64, minorRadius float64) float64 {
+	return math.Hypot(math.Hypot(point.X, point.Z)-majorRadius, point.Y) - minorRadius
+}
+
+// Capsule centered on the origin with its line segment running along the Y axis
+//
+//	HalfHeight : half of the length of the line segment, excluding the rounded caps
+//	Radius     : radius of the capsule
+func CapsuleSDF(point Vec3, halfHeight float64, radius float64) float64 {
+	point.Y -= ClampBetween(point.Y, -halfHeight, halfHeight)
+	return Length(point) - radius
+}
+
+// Capped cylinder centered on the origin running along the Y axis
+func CylinderSDF(point Vec3, halfHeight float64, radius float64) float64 {
+	dx := math.Hypot(point.X, point.Z) - radius
+	dy := math.Abs(point.Y) - halfHeight
+
+	return math.Min(math.Max(dx, dy), 0.0) + math.Hypot(math.Max(dx, 0.0), math.Max(dy, 0.0))
+}
+
+// Capped cone with its tip on the origin, opening up downwards along the Y axis
+//
+//	Angle  : angle between the Y axis and the side of the cone in radians
+//	Height : distance between the tip and the base of the cone
+func ConeSDF(point Vec3, angle float64, height float64) float64 {
+	qx := height * math.Tan(angle)
+	qy := -height
+	wx := math.Hypot(point.X, point.Z)
+	wy := point.Y
+
+	// Closest point on the side of the cone
+	t := ClampBetween((wx*qx+wy*qy)/(qx*qx+qy*qy), 0.0, 1.0)
+	ax := wx - qx*t
+	ay := wy - qy*t
+
+	// Closest point on the base of the cone
+	bx := wx - qx*ClampBetween(wx/qx, 0.0, 1.0)
+	by := wy - qy
+
+	distance := math.Sqrt(math.Min(ax*ax+ay*ay, bx*bx+by*by))
+	sign := math.Max(-(wx*qy - wy*qx), -(wy - qy))
+
+	if sign < 0.0 {
+		return -distance
+	}
+
+	return distance
+}
+
+// Infinite plane through the origin, offset along its normal
+//
+//	Normal : direction the plane faces, must be normalized
+//	Offset : distance the plane has been moved along the negated normal
+func PlaneSDF(point Vec3, normal Vec3, offset float64) float64 {
+	return Dot(point, normal) + offset
+}
+
+// Ellipsoid centered on the origin
+//
+// There is no closed form for the exact distance to an ellipsoid, this returns a bound that is exact on the
+// surface and a conservative approximation elsewhere, which is good enough for sphere tracing.
+func EllipsoidSDF(point Vec3, radii Vec3) float64 {
+	k0 := Length(Divide(point, radii))
+	k1 := Length(Divide(point, Multiply(radii, radii)))
+
+	if k1 == 0.0 {
+		return -math.Min(radii.X, math.Min(radii.Y, radii.Z))
+	}
+
+	return k0 * (k0 - 1.0) / k1
+}
+
+// Hexagonal prism centered on the origin running along the Z axis
+//
+//	Apothem    : distance from the center of the hexagon to the middle of one of its sides
+//	HalfLength : half of the length of the prism
+func HexagonalPrismSDF(point Vec3, apothem float64, halfLength float64) float64 {
+	const kx, ky, kz = -0.8660254037844386, 0.5, 0.5773502691896258
+
+	point = Abs(point)
+	fold := 2.0 * math.Min(kx*point.X+ky*point.Y, 0.0)
+	point.X -= fold * kx
+	point.Y -= fold * ky
+
+	dx := math.Hypot(point.X-ClampBetween(point.X, -kz*apothem, kz*apothem), point.Y-apothem)
+	if point.Y < apothem {
+		dx = -dx
+	}
+	dy := point.Z - halfLength
+
+	return math.Min(math.Max(dx, dy), 0.0) + math.Hypot(math.Max(dx, 0.0), math.Max(dy, 0.0))
+}
+
+// Regular octahedron centered on the origin, the size is the distance from the center to each vertex
+func OctahedronSDF(point Vec3, size float64) float64 {
+	point = Abs(point)
+	m := point.X + point.Y + point.Z - size
+
+	var q Vec3
+	if 3.0*point.X < m {
+		q = point
+	} else if 3.0*point.Y < m {
+		q = Vec3{X: point.Y, Y: point.Z, Z: point.X}
+	} else if 3.0*point.Z < m {
+		q = Vec3{X: point.Z, Y: point.X, Z: point.Y}
+	} else {
+		return m * 0.57735027
+	}
+
+	k := ClampBetween(0.5*(q.Z-q.Y+size), 0.0, size)
+	return Length(Vec3{X: q.X, Y: q.Y - size + k, Z: q.Z - k})
+}
+
+// Torus lying in the XY plane that has been cut open, leaving an arc that is symmetric around the Y axis
+//
+//	Angle       : half of the angle the arc spans in radians
+//	MajorRadius : distance from the center of the torus to the center of the tube
+//	MinorRadius : radius of the tube
+func CappedTorusSDF(point Vec3, angle float64, majorRadius float64, minorRadius float64) float64 {
+	sin, cos := math.Sincos(angle)
+	point.X = math.Abs(point.X)
+
+	k := math.Hypot(point.X, point.Y)
+	if cos*point.X > sin*point.Y {
+		k = point.X*sin + point.Y*cos
+	}
+
+	return math.Sqrt(Dot(point, point)+majorRadius*majorRadius-2.0*majorRadius*k) - minorRadius
+}
+
+// Chain link centered on the origin, the link is stretched along the Y axis
+//
+//	HalfLength  : half of the length of the straight section of the link
+//	MajorRadius : distance from the center of each rounded end to the center of the tube
+//	MinorRadius : radius of the tube
+func LinkSDF(point Vec3, halfLength float64, majorRadius float64, minorRadius float64) float64 {
+	qy := math.Max(math.Abs(point.Y)-halfLength, 0.0)
+	return math.Hypot(math.Hypot(point.X, qy)-majorRadius, point.Z) - minorRadius
+}
+
 // Mandelbulb fractal
 //
 // Adapted from: http://blog.hvidtfeldts.net/index.php/2011/09/distance-estimated-3d-fractals-v-the-mandelbulb-different-de-approximations/
 func MandelbulbSDF(point Vec3, iterations int, power int, bailout float64) float64 {
+	return MandelbulbOrbitTrapSDF(point, iterations, power, bailout, nil)
+}
+
+// Mandelbulb fractal that records the orbit of the point in the orbit trap, the trap is ignored when it is nil
+func MandelbulbOrbitTrapSDF(point Vec3, iterations int, power int, bailout float64, trap *OrbitTrap) float64 {
 	z := point
 	dr := 1.0
 	r := 0.0
@@ -26,6 +194,10 @@ func MandelbulbSDF(point Vec3, iterations int, power int, bailout float64) float
 			break
 		}
 
+		if trap != nil {
+			trap.record(z)
+		}
+
 		// Convert to polar coordinates
 		theta := math.Acos(z.Z / r)
 		phi := math.Atan2(z.Y, z.X)
@@ -41,5 +213,259 @@ func MandelbulbSDF(point Vec3, iterations int, power int, bailout float64) float
 		z.Add(point)
 	}
 
+	if trap != nil {
+		trap.finish(r, bailout, powerFloat)
+	}
+
+	return 0.5 * math.Log(r) * r / dr
+}
+
+// Menger sponge fractal filling the box between -1.0 and 1.0 on each axis
+//
+// Each iteration folds space into a single corner of the sponge and scales it up by a factor three, the distance
+// to the unit box in that folded space is scaled back down to get the distance to the sponge.
+//
+// Adapted from: http://blog.hvidtfeldts.net/index.php/2011/08/distance-estimated-3d-fractals-iii-folding-space/
+func MengerSpongeSDF(point Vec3, iterations int, bailout float64) float64 {
+	return MengerSpongeOrbitTrapSDF(point, iterations, bailout, nil)
+}
+
+// Menger sponge fractal that records the orbit of the point in the orbit trap, the trap is ignored when it is nil
+func MengerSpongeOrbitTrapSDF(point Vec3, iterations int, bailout float64, trap *OrbitTrap) float64 {
+	const scale = 3.0
+	z := point
+	distanceScale := 1.0
+
+	for i := 0; i < iterations; i++ {
+		if z.MagnitudeSqrt() > bailout {
+			break
+		}
+
+		// Fold space onto the part of the sponge where x >= y >= z >= 0
+		z = Abs(z)
+		if z.X < z.Y {
+			z.X, z.Y = z.Y, z.X
+		}
+		if z.X < z.Z {
+			z.X, z.Z = z.Z, z.X
+		}
+		if z.Y < z.Z {
+			z.Y, z.Z = z.Z, z.Y
+		}
+
+		// Scale the sub-cube in the corner up to the size of the entire sponge
+		z = Sub(MultiplyScalar(z, scale), Vec3{X: scale - 1.0, Y: scale - 1.0, Z: scale - 1.0})
+		if z.Z < -0.5*(scale-1.0) {
+			z.Z += scale - 1.0
+		}
+
+		distanceScale *= scale
+
+		if trap != nil {
+			trap.record(z)
+		}
+	}
+
+	if trap != nil {
+		trap.finishLinear(z.MagnitudeSqrt(), bailout, scale)
+	}
+
+	return BoxSDF(z, Vec3{X: 1.0, Y: 1.0, Z: 1.0}) / distanceScale
+}
+
+// Mandelbox fractal
+//
+//	Scale        : scale applied after folding, typically between -3.0 and 3.0
+//	FoldingLimit : half size of the box used for the box fold, typically 1.0
+//	MinRadius    : radius of the inner sphere used for the sphere fold, typically 0.5
+//	FixedRadius  : radius of the outer sphere used for the sphere fold, typically 1.0
+//
+// Adapted from: http://blog.hvidtfeldts.net/index.php/2011/11/distance-estimated-3d-fractals-vi-the-mandelbox/
+func MandelboxSDF(point Vec3, iterations int, scale float64, foldingLimit float64, minRadius float64, fixedRadius float64, bailout float64) float64 {
+	return MandelboxOrbitTrapSDF(point, iterations, scale, foldingLimit, minRadius, fixedRadius, bailout, nil)
+}
+
+// Mandelbox fractal that records the orbit of the point in the orbit trap, the trap is ignored when it is nil
+func MandelboxOrbitTrapSDF(point Vec3, iterations int, scale float64, foldingLimit float64, minRadius float64, fixedRadius float64, bailout float64, trap *OrbitTrap) float64 {
+	z := point
+	dr := 1.0
+	minRadiusSquared := minRadius * minRadius
+	fixedRadiusSquared := fixedRadius * fixedRadius
+
+	for i := 0; i < iterations; i++ {
+		// Box fold
+		z.X = ClampBetween(z.X, -foldingLimit, foldingLimit)*2.0 - z.X
+		z.Y = ClampBetween(z.Y, -foldingLimit, foldingLimit)*2.0 - z.Y
+		z.Z = ClampBetween(z.Z, -foldingLimit, foldingLimit)*2.0 - z.Z
+
+		// Sphere fold
+		radiusSquared := z.Magnitude()
+		if radiusSquared < minRadiusSquared {
+			factor := fixedRadiusSquared / minRadiusSquared
+			z.MultiplyWith(factor)
+			dr *= factor
+		} else if radiusSquared < fixedRadiusSquared {
+			factor := fixedRadiusSquared / radiusSquared
+			z.MultiplyWith(factor)
+			dr *= factor
+		}
+
+		z = Add(MultiplyScalar(z, scale), point)
+		dr = dr*math.Abs(scale) + 1.0
+
+		if trap != nil {
+			trap.record(z)
+		}
+
+		if z.MagnitudeSqrt() > bailout {
+			break
+		}
+	}
+
+	if trap != nil {
+		trap.finishLinear(z.MagnitudeSqrt(), bailout, math.Abs(scale))
+	}
+
+	return z.MagnitudeSqrt() / math.Abs(dr)
+}
+
+// Sierpinski tetrahedron fractal with its vertices on alternating corners of the box between -1.0 and 1.0
+//
+//	Scale : scale applied after folding, a scale of 2.0 results in the classic Sierpinski tetrahedron
+//
+// Adapted from: http://blog.hvidtfeldts.net/index.php/2011/08/distance-estimated-3d-fractals-iii-folding-space/
+func SierpinskiTetrahedronSDF(point Vec3, iterations int, scale float64, bailout float64) float64 {
+	return SierpinskiTetrahedronOrbitTrapSDF(point, iterations, scale, bailout, nil)
+}
+
+// Sierpinski tetrahedron fractal that records the orbit of the point in the orbit trap, the trap is ignored when it
+// is nil
+func SierpinskiTetrahedronOrbitTrapSDF(point Vec3, iterations int, scale float64, bailout float64, trap *OrbitTrap) float64 {
+	z := point
+	distanceScale := 1.0
+
+	for i := 0; i < iterations; i++ {
+		if z.MagnitudeSqrt() > bailout {
+			break
+		}
+
+		// Fold space across the symmetry planes of the tetrahedron
+		if z.X+z.Y < 0.0 {
+			z.X, z.Y = -z.Y, -z.X
+		}
+		if z.X+z.Z < 0.0 {
+			z.X, z.Z = -z.Z, -z.X
+		}
+		if z.Y+z.Z < 0.0 {
+			z.Y, z.Z = -z.Z, -z.Y
+		}
+
+		// Scale the sub-tetrahedron in the corner up to the size of the entire tetrahedron
+		z = Sub(MultiplyScalar(z, scale), Vec3{X: scale - 1.0, Y: scale - 1.0, Z: scale - 1.0})
+		distanceScale *= scale
+
+		if trap != nil {
+			trap.record(z)
+		}
+	}
+
+	if trap != nil {
+		trap.finishLinear(z.MagnitudeSqrt(), bailout, scale)
+	}
+
+	return z.MagnitudeSqrt() / distanceScale
+}
+
+// Kaleidoscopic iterated function system fractal
+//
+//	Scale    : scale applied after folding, values above 1.0 result in a fractal
+//	Offset   : point space is scaled away from after folding, this determines the shape of the fractal
+//	Rotation : rotation applied after folding, this breaks the symmetry of the folds
+//
+// Reference: http://www.fractalforums.com/ifs-iterated-function-systems/kaleidoscopic-(escape-time-ifs)/
+func KaleidoscopicIFSSDF(point Vec3, iterations int, scale float64, offset Vec3, rotation Mat3, bailout float64) float64 {
+	return KaleidoscopicIFSOrbitTrapSDF(point, iterations, scale, offset, rotation, bailout, nil)
+}
+
+// Kaleidoscopic iterated function system fractal that records the orbit of the point in the orbit trap, the trap is
+// ignored when it is nil
+func KaleidoscopicIFSOrbitTrapSDF(point Vec3, iterations int, scale float64, offset Vec3, rotation Mat3, bailout float64, trap *OrbitTrap) float64 {
+	z := point
+	distanceScale := 1.0
+
+	for i := 0; i < iterations; i++ {
+		if z.MagnitudeSqrt() > bailout {
+			break
+		}
+
+		// Octahedral folds
+		z = Abs(z)
+		if z.X < z.Y {
+			z.X, z.Y = z.Y, z.X
+		}
+		if z.X < z.Z {
+			z.X, z.Z = z.Z, z.X
+		}
+		if z.Y < z.Z {
+			z.Y, z.Z = z.Z, z.Y
+		}
+
+		z = MultiplyMat3Vec3(rotation, z)
+		z = Sub(MultiplyScalar(z, scale), MultiplyScalar(offset, scale-1.0))
+		distanceScale *= scale
+
+		if trap != nil {
+			trap.record(z)
+		}
+	}
+
+	if trap != nil {
+		trap.finishLinear(z.MagnitudeSqrt(), bailout, scale)
+	}
+
+	return z.MagnitudeSqrt() / distanceScale
+}
+
+// Quaternion Julia set fractal, a 3D slice through the 4D set of quaternions that remain bounded under z = z^2 + c
+//
+//	Constant : constant c that determines the shape of the set
+//	Slice    : value of the fourth dimension at which the 3D slice is taken
+//
+// Adapted from: https://iquilezles.org/articles/juliasets3d/
+func QuaternionJuliaSDF(point Vec3, constant Quaternion, slice float64, iterations int, bailout float64) float64 {
+	return QuaternionJuliaOrbitTrapSDF(point, constant, slice, iterations, bailout, nil)
+}
+
+// Quaternion Julia set fractal that records the orbit of the 3D part of the point in the orbit trap, the trap is
+// ignored when it is nil
+func QuaternionJuliaOrbitTrapSDF(point Vec3, constant Quaternion, slice float64, iterations int, bailout float64, trap *OrbitTrap) float64 {
+	z := Quaternion{W: point.X, X: point.Y, Y: point.Z, Z: slice}
+	dr := 1.0
+	r := z.Length()
+
+	for i := 0; i < iterations; i++ {
+		// Derivative of z^2 is 2z
+		dr = 2.0 * r * dr
+		z = AddQuaternion(MultiplyQuaternion(z, z), constant)
+
+		if trap != nil {
+			trap.record(Vec3{X: z.W, Y: z.X, Z: z.Y})
+		}
+
+		r = z.Length()
+		if r > bailout {
+			break
+		}
+	}
+
+	if trap != nil {
+		trap.finish(r, bailout, 2.0)
+	}
+
+	// The orbit collapsed onto zero or the derivative underflowed, which only happens deep inside of the set
+	if r == 0.0 || dr == 0.0 {
+		return -epsilon
+	}
+
 	return 0.5 * math.Log(r) * r / dr
 }
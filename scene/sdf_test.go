@@ -0,0 +1,257 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+const tolerance = 0.0001
+
+// Sample point and the distance a primitive is expected to return for it
+type distanceSample struct {
+	point    Vec3
+	distance float64
+}
+
+func verifyDistances(t *testing.T, name string, shape sdf, samples []distanceSample) {
+	for _, sample := range samples {
+		distance := shape(sample.point)
+
+		if math.Abs(distance-sample.distance) > tolerance {
+			t.Fatalf("%s failure: expected a distance of %f at %v but got %f", name, sample.distance, sample.point, distance)
+		}
+	}
+}
+
+func TestSphereSDF(t *testing.T) {
+	verifyDistances(t, "SphereSDF", func(p Vec3) float64 { return SphereSDF(p, 1.0) }, []distanceSample{
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, -1.0},
+		{Vec3{X: 3.0, Y: 0.0, Z: 0.0}, 2.0},
+		{Vec3{X: 0.0, Y: 0.6, Z: 0.8}, 0.0},
+	})
+}
+
+func TestBoxSDF(t *testing.T) {
+	verifyDistances(t, "BoxSDF", func(p Vec3) float64 { return BoxSDF(p, Vec3{X: 1.0, Y: 2.0, Z: 3.0}) }, []distanceSample{
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, -1.0},
+		{Vec3{X: 2.0, Y: 0.0, Z: 0.0}, 1.0},
+		{Vec3{X: 0.0, Y: -5.0, Z: 0.0}, 3.0},
+		{Vec3{X: 4.0, Y: 6.0, Z: 0.0}, 5.0},
+		{Vec3{X: 1.0, Y: 2.0, Z: 3.0}, 0.0},
+	})
+}
+
+func TestRoundedBoxSDF(t *testing.T) {
+	verifyDistances(t, "RoundedBoxSDF", func(p Vec3) float64 { return RoundedBoxSDF(p, Vec3{X: 1.0, Y: 1.0, Z: 1.0}, 0.25) }, []distanceSample{
+		{Vec3{X: 2.0, Y: 0.0, Z: 0.0}, 1.0},
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, -1.0},
+		{Vec3{X: 1.75, Y: 1.75, Z: 0.0}, math.Sqrt2 - 0.25},
+	})
+}
+
+func TestTorusSDF(t *testing.T) {
+	verifyDistances(t, "TorusSDF", func(p Vec3) float64 { return TorusSDF(p, 2.0, 0.5) }, []distanceSample{
+		{Vec3{X: 2.0, Y: 0.0, Z: 0.0}, -0.5},
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, 1.5},
+		{Vec3{X: 0.0, Y: 3.0, Z: 2.0}, 2.5},
+		{Vec3{X: 0.0, Y: 0.0, Z: -4.0}, 1.5},
+	})
+}
+
+func TestCapsuleSDF(t *testing.T) {
+	verifyDistances(t, "CapsuleSDF", func(p Vec3) float64 { return CapsuleSDF(p, 1.0, 0.5) }, []distanceSample{
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, -0.5},
+		{Vec3{X: 2.0, Y: 0.5, Z: 0.0}, 1.5},
+		{Vec3{X: 0.0, Y: 3.0, Z: 0.0}, 1.5},
+		{Vec3{X: 0.0, Y: -1.5, Z: 0.0}, 0.0},
+	})
+}
+
+func TestCylinderSDF(t *testing.T) {
+	verifyDistances(t, "CylinderSDF", func(p Vec3) float64 { return CylinderSDF(p, 1.0, 2.0) }, []distanceSample{
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, -1.0},
+		{Vec3{X: 0.0, Y: 0.0, Z: 5.0}, 3.0},
+		{Vec3{X: 0.0, Y: 4.0, Z: 0.0}, 3.0},
+		{Vec3{X: 5.0, Y: 5.0, Z: 0.0}, 5.0},
+	})
+}
+
+func TestConeSDF(t *testing.T) {
+	verifyDistances(t, "ConeSDF", func(p Vec3) float64 { return ConeSDF(p, math.Pi/4.0, 1.0) }, []distanceSample{
+		{Vec3{X: 0.0, Y: 1.0, Z: 0.0}, 1.0},
+		{Vec3{X: 0.0, Y: -3.0, Z: 0.0}, 2.0},
+		{Vec3{X: 0.0, Y: -0.5, Z: 0.0}, -math.Sqrt2 / 4.0},
+		{Vec3{X: 1.0, Y: 0.0, Z: 0.0}, math.Sqrt2 / 2.0},
+		{Vec3{X: 0.5, Y: -0.5, Z: 0.0}, 0.0},
+	})
+}
+
+func TestPlaneSDF(t *testing.T) {
+	verifyDistances(t, "PlaneSDF", func(p Vec3) float64 { return PlaneSDF(p, Vec3{X: 0.0, Y: 1.0, Z: 0.0}, 1.0) }, []distanceSample{
+		{Vec3{X: 5.0, Y: 0.0, Z: -3.0}, 1.0},
+		{Vec3{X: 0.0, Y: -1.0, Z: 0.0}, 0.0},
+		{Vec3{X: 0.0, Y: -4.0, Z: 7.0}, -3.0},
+	})
+}
+
+func TestEllipsoidSDF(t *testing.T) {
+	radii := Vec3{X: 1.0, Y: 2.0, Z: 3.0}
+	ellipsoid := func(p Vec3) float64 { return EllipsoidSDF(p, radii) }
+
+	// Exact on the surface
+	verifyDistances(t, "EllipsoidSDF", ellipsoid, []distanceSample{
+		{Vec3{X: 1.0, Y: 0.0, Z: 0.0}, 0.0},
+		{Vec3{X: 0.0, Y: -2.0, Z: 0.0}, 0.0},
+		{Vec3{X: 0.0, Y: 0.0, Z: 3.0}, 0.0},
+	})
+
+	// Never overestimates the distance outside of the ellipsoid, the upper bounds are the distances to the nearest vertex
+	verifyBound := func(point Vec3, upperBound float64) {
+		distance := ellipsoid(point)
+
+		if distance <= 0.0 || distance > upperBound+tolerance {
+			t.Fatalf("EllipsoidSDF failure: distance %f at %v is not within (0.0, %f]", distance, point, upperBound)
+		}
+	}
+
+	verifyBound(Vec3{X: 3.0, Y: 0.0, Z: 0.0}, 2.0)
+	verifyBound(Vec3{X: 0.0, Y: 5.0, Z: 0.0}, 3.0)
+	verifyBound(Vec3{X: 0.0, Y: 0.0, Z: -4.0}, 1.0)
+}
+
+func TestHexagonalPrismSDF(t *testing.T) {
+	verifyDistances(t, "HexagonalPrismSDF", func(p Vec3) float64 { return HexagonalPrismSDF(p, 1.0, 2.0) }, []distanceSample{
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, -1.0},
+		{Vec3{X: 0.0, Y: 3.0, Z: 0.0}, 2.0},
+		{Vec3{X: 0.0, Y: 0.0, Z: 5.0}, 3.0},
+		{Vec3{X: 0.0, Y: 1.0, Z: 0.0}, 0.0},
+		{Vec3{X: 2.0 / math.Sqrt(3.0), Y: 0.0, Z: 0.0}, 0.0},
+	})
+}
+
+func TestOctahedronSDF(t *testing.T) {
+	verifyDistances(t, "OctahedronSDF", func(p Vec3) float64 { return OctahedronSDF(p, 1.0) }, []distanceSample{
+		{Vec3{X: 3.0, Y: 0.0, Z: 0.0}, 2.0},
+		{Vec3{X: 0.0, Y: 0.0, Z: 1.0}, 0.0},
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, -1.0 / math.Sqrt(3.0)},
+		{Vec3{X: 1.0, Y: 1.0, Z: 1.0}, 2.0 / math.Sqrt(3.0)},
+	})
+}
+
+func TestCappedTorusSDF(t *testing.T) {
+	// Arc spanning the upper half of the torus
+	verifyDistances(t, "CappedTorusSDF", func(p Vec3) float64 { return CappedTorusSDF(p, math.Pi/2.0, 2.0, 0.5) }, []distanceSample{
+		{Vec3{X: 2.0, Y: 0.0, Z: 0.0}, -0.5},
+		{Vec3{X: 0.0, Y: 2.0, Z: 0.0}, -0.5},
+		{Vec3{X: 0.0, Y: 0.0, Z: 1.0}, math.Sqrt(5.0) - 0.5},
+		{Vec3{X: 0.0, Y: -3.0, Z: 0.0}, math.Sqrt(13.0) - 0.5},
+	})
+
+	// Arc spanning a quarter of the torus, ending at 45 degrees on either side of the Y axis
+	verifyDistances(t, "CappedTorusSDF", func(p Vec3) float64 { return CappedTorusSDF(p, math.Pi/4.0, 2.0, 0.5) }, []distanceSample{
+		{Vec3{X: 0.0, Y: 3.0, Z: 0.0}, 0.5},
+		{Vec3{X: 0.0, Y: -2.0, Z: 0.0}, math.Sqrt(8.0+4.0*math.Sqrt2) - 0.5},
+	})
+}
+
+func TestLinkSDF(t *testing.T) {
+	verifyDistances(t, "LinkSDF", func(p Vec3) float64 { return LinkSDF(p, 1.0, 1.0, 0.25) }, []distanceSample{
+		{Vec3{X: 1.0, Y: 0.0, Z: 0.0}, -0.25},
+		{Vec3{X: 0.0, Y: 2.0, Z: 0.0}, -0.25},
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, 0.75},
+		{Vec3{X: 1.0, Y: 0.5, Z: 2.0}, 1.75},
+	})
+}
+
+func TestMengerSpongeSDF(t *testing.T) {
+	menger := func(point Vec3) float64 { return MengerSpongeSDF(point, 4, 100.0) }
+
+	// Corners of the sponge are solid while the center of each face has been carved out
+	if menger(Vec3{X: 0.999, Y: 0.999, Z: 0.999}) >= 0.0 {
+		t.Fatalf("MengerSpongeSDF failure: the corner of the sponge should be solid")
+	}
+
+	if menger(Vec3{X: 0.0, Y: 0.0, Z: 0.0}) <= 0.0 || menger(Vec3{X: 0.95, Y: 0.0, Z: 0.0}) <= 0.0 {
+		t.Fatalf("MengerSpongeSDF failure: the center of the sponge should be empty")
+	}
+
+	// Facing the hole in the center of a face, the closest point on the sponge is on the rim of the hole
+	verifyDistances(t, "MengerSpongeSDF", menger, []distanceSample{
+		{Vec3{X: 0.0, Y: 3.0, Z: 0.0}, math.Sqrt(4.0 + 1.0/9.0)},
+		{Vec3{X: 0.0, Y: 0.0, Z: -1.5}, math.Sqrt(0.25 + 1.0/9.0)},
+		{Vec3{X: 3.0, Y: 3.0, Z: 3.0}, math.Sqrt(12.0)},
+	})
+}
+
+func TestMandelboxSDF(t *testing.T) {
+	mandelbox := func(point Vec3) float64 { return MandelboxSDF(point, 12, 2.0, 1.0, 0.5, 1.0, 100.0) }
+
+	if distance := mandelbox(Vec3{X: 0.0, Y: 0.0, Z: 0.0}); distance > tolerance {
+		t.Fatalf("MandelboxSDF failure: the origin should be part of the set but got a distance of %f", distance)
+	}
+
+	if distance := mandelbox(Vec3{X: 20.0, Y: 0.0, Z: 0.0}); distance <= 0.0 || distance > 20.0 {
+		t.Fatalf("MandelboxSDF failure: expected a positive bound for a point far outside the set but got %f", distance)
+	}
+}
+
+func TestSierpinskiTetrahedronSDF(t *testing.T) {
+	sierpinski := func(point Vec3) float64 { return SierpinskiTetrahedronSDF(point, 12, 2.0, 100.0) }
+
+	if distance := sierpinski(Vec3{X: 1.0, Y: 1.0, Z: 1.0}); distance > 0.001 {
+		t.Fatalf("SierpinskiTetrahedronSDF failure: vertices should lie on the fractal but got a distance of %f", distance)
+	}
+
+	// The distance to the nearest vertex is an upper bound for the distance to the fractal
+	if distance := sierpinski(Vec3{X: 10.0, Y: 0.0, Z: 0.0}); distance <= 0.0 || distance > math.Sqrt(83.0) {
+		t.Fatalf("SierpinskiTetrahedronSDF failure: expected a distance within (0.0, %f] but got %f", math.Sqrt(83.0), distance)
+	}
+}
+
+func TestKaleidoscopicIFSSDF(t *testing.T) {
+	offset := Vec3{X: 1.0, Y: 1.0, Z: 1.0}
+	kifs := func(point Vec3) float64 { return KaleidoscopicIFSSDF(point, 12, 2.0, offset, IdentityMat3(), 100.0) }
+
+	if distance := kifs(offset); distance > 0.001 {
+		t.Fatalf("KaleidoscopicIFSSDF failure: the offset should be a fixed point of the fractal but got a distance of %f", distance)
+	}
+
+	if distance := kifs(Vec3{X: 10.0, Y: 0.0, Z: 0.0}); distance <= 0.0 || distance > math.Sqrt(83.0) {
+		t.Fatalf("KaleidoscopicIFSSDF failure: expected a distance within (0.0, %f] but got %f", math.Sqrt(83.0), distance)
+	}
+}
+
+func TestQuaternionJuliaSDF(t *testing.T) {
+	// Without a constant the set is the unit hypersphere, which makes the slice through it a unit sphere
+	julia := func(point Vec3) float64 { return QuaternionJuliaSDF(point, Quaternion{}, 0.0, 12, 4.0) }
+
+	for _, point := range []Vec3{{X: 0.5, Y: 0.0, Z: 0.0}, {}} {
+		if distance := julia(point); math.IsNaN(distance) || distance >= 0.0 {
+			t.Fatalf("QuaternionJuliaSDF failure: expected a point inside of the set to be negative but got %f", distance)
+		}
+	}
+
+	if distance := julia(Vec3{X: 0.0, Y: 2.0, Z: 0.0}); distance <= 0.0 || distance > 1.0 {
+		t.Fatalf("QuaternionJuliaSDF failure: expected a distance within (0.0, 1.0] but got %f", distance)
+	}
+
+	// A slice beyond the radius of the hypersphere does not contain any part of the set
+	if distance := QuaternionJuliaSDF(Vec3{}, Quaternion{}, 1.5, 12, 4.0); distance <= 0.0 {
+		t.Fatalf("QuaternionJuliaSDF failure: expected a slice outside of the set to be positive but got %f", distance)
+	}
+}
+
+func TestQuaternionJuliaSDFBasilica(t *testing.T) {
+	constant := Quaternion{W: -1.0, X: 0.0, Y: 0.0, Z: 0.0}
+	julia := func(point Vec3) float64 { return QuaternionJuliaSDF(point, constant, 0.0, 64, 4.0) }
+
+	if distance := julia(Vec3{X: 0.1, Y: 0.0, Z: 0.0}); math.IsNaN(distance) || distance >= 0.0 {
+		t.Fatalf("QuaternionJuliaSDF failure: expected a point inside of the set to be negative but got %f", distance)
+	}
+
+	if distance := julia(Vec3{X: 0.0, Y: 0.0, Z: 1.5}); distance <= 0.0 {
+		t.Fatalf("QuaternionJuliaSDF failure: expected a point outside of the set to be positive but got %f", distance)
+	}
+}
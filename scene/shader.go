@@ -0,0 +1,143 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Everything a shader needs to know to calculate the color of a surface point, all colors are linear
+type ShadingContext struct {
+	Point, Normal, ViewDirection Vec3
+	Material                     Material
+	AmbientLight                 Vec3
+	Lights                       []LightSample
+}
+
+// Calculates the linear color of a surface point
+type Shader interface {
+	Shade(context ShadingContext) Vec3
+}
+
+// Blinn-Phong lighting model, the shininess is derived from the roughness of the material. Both terms are normalized
+// to conserve energy, so lights have the same strength as with the Cook-Torrance shader.
+//
+// Reference: https://learnopengl.com/Advanced-Lighting/Advanced-Lighting
+type BlinnPhongShader struct{}
+
+// Metallic/roughness Cook-Torrance BRDF with the GGX normal distribution, the Smith-Schlick geometry term, and the
+// Schlick Fresnel approximation
+//
+// Reference: https://learnopengl.com/PBR/Theory
+type CookTorranceShader struct{}
+
+// Create a new Blinn-Phong shader
+func NewBlinnPhongShader() *BlinnPhongShader {
+	return &BlinnPhongShader{}
+}
+
+// Create a new Cook-Torrance shader
+func NewCookTorranceShader() *CookTorranceShader {
+	return &CookTorranceShader{}
+}
+
+// Convert a roughness value into a Blinn-Phong shininess exponent
+func roughnessToShininess(roughness float64) float64 {
+	return 2.0/math.Max(math.Pow(roughness, 4.0), 0.0001) - 2.0
+}
+
+// Calculate the color of a surface point using the Blinn-Phong lighting model
+func (s *BlinnPhongShader) Shade(context ShadingContext) Vec3 {
+	material := context.Material
+	shininess := roughnessToShininess(material.Roughness)
+	specularNormalization := (shininess + 8.0) / (8.0 * math.Pi)
+	lightColor := context.AmbientLight
+
+	for _, light := range context.Lights {
+		halfwayDirection := Normalize(Add(light.Direction, context.ViewDirection))
+		normalDotLight := math.Max(Dot(context.Normal, light.Direction), 0.0)
+
+		diffuse := normalDotLight / math.Pi
+		specular := math.Pow(math.Max(Dot(context.Normal, halfwayDirection), 0.0), shininess) * specularNormalization * normalDotLight * material.Specular
+
+		lightColor.Add(MultiplyScalar(light.Radiance, diffuse+specular))
+	}
+
+	return Add(Multiply(lightColor, material.Albedo), material.Emissive)
+}
+
+// Reflectance of a surface when looking at it head-on, dielectrics reflect a small amount of white light while
+// metals tint the reflection with their albedo
+func baseReflectance(material Material) Vec3 {
+	dielectric := 0.08 * material.Specular
+	return Add(MultiplyScalar(Vec3{X: dielectric, Y: dielectric, Z: dielectric}, 1.0-material.Metallic), MultiplyScalar(material.Albedo, material.Metallic))
+}
+
+// Schlick approximation of the Fresnel equations
+func fresnelSchlick(cosTheta float64, f0 Vec3) Vec3 {
+	factor := math.Pow(1.0-ClampBetween(cosTheta, 0.0, 1.0), 5.0)
+	return Add(f0, MultiplyScalar(Sub(Vec3{X: 1.0, Y: 1.0, Z: 1.0}, f0), factor))
+}
+
+// GGX (Trowbridge-Reitz) normal distribution function
+func distributionGGX(normalDotHalfway float64, roughness float64) float64 {
+	alpha := roughness * roughness
+	alphaSquared := alpha * alpha
+	denominator := normalDotHalfway*normalDotHalfway*(alphaSquared-1.0) + 1.0
+
+	return alphaSquared / math.Max(math.Pi*denominator*denominator, 1e-12)
+}
+
+// Smith geometry function using the Schlick-GGX approximation for both the light and the view direction
+func geometrySmith(normalDotView float64, normalDotLight float64, roughness float64) float64 {
+	k := (roughness + 1.0) * (roughness + 1.0) / 8.0
+	view := normalDotView / (normalDotView*(1.0-k) + k)
+	light := normalDotLight / (normalDotLight*(1.0-k) + k)
+
+	return view * light
+}
+
+// Evaluate the Cook-Torrance BRDF for a pair of directions, the result still has to be multiplied by the incoming
+// radiance and the cosine between the normal and the light direction
+func cookTorranceBRDF(material Material, normal Vec3, viewDirection Vec3, lightDirection Vec3) Vec3 {
+	normalDotLight := Dot(normal, lightDirection)
+	if normalDotLight <= 0.0 {
+		return Vec3{}
+	}
+
+	roughness := math.Max(material.Roughness, 0.02)
+	normalDotView := math.Max(Dot(normal, viewDirection), 1e-4)
+	halfwayDirection := Normalize(Add(lightDirection, viewDirection))
+	normalDotHalfway := math.Max(Dot(normal, halfwayDirection), 0.0)
+	viewDotHalfway := math.Max(Dot(viewDirection, halfwayDirection), 0.0)
+
+	fresnel := fresnelSchlick(viewDotHalfway, baseReflectance(material))
+	distribution := distributionGGX(normalDotHalfway, roughness)
+	geometry := geometrySmith(normalDotView, normalDotLight, roughness)
+
+	// Metals do not have a diffuse component
+	diffuseColor := MultiplyScalar(material.Albedo, (1.0-material.Metallic)/math.Pi)
+
+	specular := MultiplyScalar(fresnel, distribution*geometry/(4.0*normalDotView*normalDotLight))
+	diffuse := Multiply(Sub(Vec3{X: 1.0, Y: 1.0, Z: 1.0}, fresnel), diffuseColor)
+
+	return Add(diffuse, specular)
+}
+
+// Calculate the color of a surface point using the Cook-Torrance BRDF
+func (s *CookTorranceShader) Shade(context ShadingContext) Vec3 {
+	material := context.Material
+	outputColor := Multiply(context.AmbientLight, Add(MultiplyScalar(material.Albedo, 1.0-material.Metallic), baseReflectance(material)))
+
+	for _, light := range context.Lights {
+		normalDotLight := Dot(context.Normal, light.Direction)
+		if normalDotLight <= 0.0 {
+			continue
+		}
+
+		brdf := cookTorranceBRDF(material, context.Normal, context.ViewDirection, light.Direction)
+		outputColor.Add(Multiply(brdf, MultiplyScalar(light.Radiance, normalDotLight)))
+	}
+
+	return Add(outputColor, material.Emissive)
+}
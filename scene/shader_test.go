@@ -0,0 +1,84 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+func headOnContext(material Material) ShadingContext {
+	up := Vec3{Y: 1.0}
+
+	return ShadingContext{
+		Normal:        up,
+		ViewDirection: up,
+		Material:      material,
+		Lights:        []LightSample{{Direction: up, Distance: 1.0, Radiance: white}},
+	}
+}
+
+func TestBlinnPhongShaderAmbientAndEmissive(t *testing.T) {
+	material := NewMaterial(Vec3{X: 0.5, Y: 0.5, Z: 0.5}, 0.5, 0.0)
+	material.Emissive = Vec3{Z: 1.0}
+	context := ShadingContext{Normal: Vec3{Y: 1.0}, ViewDirection: Vec3{Y: 1.0}, Material: material, AmbientLight: white}
+	result := NewBlinnPhongShader().Shade(context)
+
+	if result != (Vec3{X: 0.5, Y: 0.5, Z: 1.5}) {
+		t.Fatalf("Blinn-Phong failure: expected {0.5 0.5 1.5} but got %v", result)
+	}
+}
+
+func TestBlinnPhongShaderHeadOn(t *testing.T) {
+	material := NewMaterial(white, 0.5, 0.0)
+	result := NewBlinnPhongShader().Shade(headOnContext(material))
+
+	// Full diffuse plus the full specular highlight, both normalized like the Cook-Torrance shader
+	shininess := roughnessToShininess(material.Roughness)
+	expected := (1.0 + material.Specular*(shininess+8.0)/8.0) / math.Pi
+
+	if math.Abs(result.X-expected) > tolerance {
+		t.Fatalf("Blinn-Phong failure: expected %f but got %f", expected, result.X)
+	}
+}
+
+func TestBlinnPhongShaderMatchesCookTorranceDiffuse(t *testing.T) {
+	material := NewMaterial(white, 1.0, 0.0)
+	material.Specular = 0.0
+	blinnPhong := NewBlinnPhongShader().Shade(headOnContext(material))
+	cookTorrance := NewCookTorranceShader().Shade(headOnContext(material))
+
+	if math.Abs(blinnPhong.X-cookTorrance.X) > tolerance {
+		t.Fatalf("Blinn-Phong failure: expected the diffuse reflection of %f to match Cook-Torrance but got %f", cookTorrance.X, blinnPhong.X)
+	}
+}
+
+func TestCookTorranceShaderDiffuse(t *testing.T) {
+	material := NewMaterial(white, 1.0, 0.0)
+	material.Specular = 0.0
+	result := NewCookTorranceShader().Shade(headOnContext(material))
+
+	// Without any specular reflection only the Lambertian diffuse term remains
+	if math.Abs(result.X-1.0/math.Pi) > tolerance {
+		t.Fatalf("Cook-Torrance failure: expected %f but got %f", 1.0/math.Pi, result.X)
+	}
+}
+
+func TestCookTorranceShaderMetalTintsReflection(t *testing.T) {
+	gold := NewMaterial(Vec3{X: 1.0, Y: 0.8, Z: 0.3}, 0.3, 1.0)
+	result := NewCookTorranceShader().Shade(headOnContext(gold))
+
+	if result.X <= result.Z {
+		t.Fatalf("Cook-Torrance failure: expected the reflection of a metal to be tinted by its albedo but got %v", result)
+	}
+}
+
+func TestCookTorranceShaderIgnoresLightsBehindSurface(t *testing.T) {
+	context := headOnContext(NewMaterial(white, 0.5, 0.0))
+	context.Lights[0].Direction = Vec3{Y: -1.0}
+	result := NewCookTorranceShader().Shade(context)
+
+	if result != (Vec3{}) {
+		t.Fatalf("Cook-Torrance failure: expected lights behind the surface to be ignored but got %v", result)
+	}
+}
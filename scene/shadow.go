@@ -0,0 +1,90 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Settings that control how shadow rays are marched through a scene
+type ShadowSettings struct {
+	Bias, MinHitDistance, MaxDistance float64
+	MaxIterations                     int
+}
+
+// Create new shadow settings with the following properties:
+//
+//	Bias           : distance the shadow ray starts away from the surface to prevent it from hitting its own surface
+//	MinHitDistance : distance to a surface below which the shadow ray is considered to be blocked
+//	MaxDistance    : maximum distance a shadow ray travels towards lights that are infinitely far away
+//	MaxIterations  : maximum number of steps before the shadow ray gives up and considers the light to be visible
+func NewShadowSettings(bias float64, minHitDistance float64, maxDistance float64, maxIterations int) ShadowSettings {
+	return ShadowSettings{bias, minHitDistance, maxDistance, maxIterations}
+}
+
+// Calculate how much of a light sample reaches a surface point, 1.0 means fully lit and 0.0 means fully shadowed.
+//
+// A softness of zero results in hard shadows. Larger values widen the penumbra by darkening points whose shadow
+// ray passes close to a surface without hitting it, using the closest distance relative to the distance travelled.
+//
+// Reference: https://iquilezles.org/articles/rmshadows/
+func (s *Scene) ShadowFactor(point Vec3, normal Vec3, sample LightSample, softness float64, settings ShadowSettings) float64 {
+	origin := Add(point, MultiplyScalar(normal, settings.Bias))
+	maxDistance := math.Min(sample.Distance, settings.MaxDistance)
+	visibility := 1.0
+	previousDistance := math.Inf(1)
+	distance := settings.MinHitDistance
+
+	for i := 0; i < settings.MaxIterations && distance < maxDistance; i++ {
+		closestDistance := s.sceneSDF(Add(origin, MultiplyScalar(sample.Direction, distance)))
+
+		if closestDistance < settings.MinHitDistance {
+			return 0.0
+		}
+
+		// Estimate the closest distance between the ray and the surface in between two steps, this falls back to
+		// the distance at the current step when the ray is moving away from the surface
+		if softness > 0.0 {
+			y := closestDistance * closestDistance / (2.0 * previousDistance)
+
+			if y < closestDistance {
+				d := math.Sqrt(closestDistance*closestDistance - y*y)
+				visibility = math.Min(visibility, d/(softness*math.Max(distance-y, epsilon)))
+			} else {
+				visibility = math.Min(visibility, closestDistance/(softness*distance))
+			}
+
+			previousDistance = closestDistance
+		}
+
+		distance += closestDistance
+	}
+
+	return ClampBetween(visibility, 0.0, 1.0)
+}
+
+// Gather the light that reaches a surface point from all lights in the scene. Samples from behind the surface
+// are skipped and the radiance of every other sample has been attenuated by its shadow.
+func (s *Scene) GatherVisibleLight(point Vec3, normal Vec3, settings ShadowSettings) []LightSample {
+	visible := []LightSample{}
+
+	for _, light := range s.lights {
+		softness := light.GetProperties().ShadowSoftness
+
+		for _, sample := range SampleLight(light, point) {
+			if Dot(normal, sample.Direction) <= 0.0 {
+				continue
+			}
+
+			shadow := s.ShadowFactor(point, normal, sample, softness, settings)
+			if shadow <= 0.0 {
+				continue
+			}
+
+			sample.Radiance.MultiplyWith(shadow)
+			visible = append(visible, sample)
+		}
+	}
+
+	return visible
+}
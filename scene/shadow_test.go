@@ -0,0 +1,47 @@
+package scene
+
+import (
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+var testShadowSettings = NewShadowSettings(0.001, 0.0001, 100.0, 256)
+
+// Ground plane with a unit sphere floating above the origin
+func shadowTestScene() Scene {
+	ground := NewPrimitiveNode(func(point Vec3) float64 { return PlaneSDF(point, Vec3{Y: 1.0}, 0.0) })
+	sphere := NewTransformNode(NewSphereNode(1.0), NewTransform(Vec3{Y: 3.0}, IdentityQuaternion(), 1.0))
+
+	return NewSceneFromGraph(NewUnionNode(ground, sphere))
+}
+
+func TestHardShadow(t *testing.T) {
+	scene := shadowTestScene()
+	light := NewPointLight(Vec3{Y: 10.0}, white, 1.0, NoAttenuation())
+	normal := Vec3{Y: 1.0}
+
+	if shadow := scene.ShadowFactor(Vec3{}, normal, light.Sample(Vec3{}, 0.5, 0.5), 0.0, testShadowSettings); shadow != 0.0 {
+		t.Fatalf("Shadow failure: expected the point below the sphere to be in shadow but got %f", shadow)
+	}
+
+	lit := Vec3{X: 5.0}
+	if shadow := scene.ShadowFactor(lit, normal, light.Sample(lit, 0.5, 0.5), 0.0, testShadowSettings); shadow != 1.0 {
+		t.Fatalf("Shadow failure: expected the point away from the sphere to be lit but got %f", shadow)
+	}
+}
+
+func TestSoftShadowPenumbra(t *testing.T) {
+	scene := shadowTestScene()
+	light := NewDirectionalLight(Vec3{Y: -1.0}, white, 1.0)
+	normal := Vec3{Y: 1.0}
+
+	// Just outside of the shadow of the sphere the shadow ray passes close by the sphere
+	point := Vec3{X: 1.1}
+	hard := scene.ShadowFactor(point, normal, light.Sample(point, 0.5, 0.5), 0.0, testShadowSettings)
+	soft := scene.ShadowFactor(point, normal, light.Sample(point, 0.5, 0.5), 0.1, testShadowSettings)
+
+	if hard != 1.0 || soft <= 0.0 || soft >= 1.0 {
+		t.Fatalf("Shadow failure: expected a partial shadow in the penumbra but got %f (hard shadow %f)", soft, hard)
+	}
+}
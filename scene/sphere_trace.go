@@ -0,0 +1,84 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Settings that control how a ray is sphere traced through a scene
+type SphereTraceSettings struct {
+	Relaxation     float64
+	MinHitDistance float64
+	MaxIterations  int
+}
+
+// Create new sphere tracing settings with the following properties:
+//
+//	Relaxation     : factor each step is multiplied with, 1.0 is regular sphere tracing while values in (1.0, 2.0) over-relax
+//	MinHitDistance : distance to the surface below which the ray is considered to have hit the surface
+//	MaxIterations  : maximum number of steps before the ray gives up
+func NewSphereTraceSettings(relaxation float64, minHitDistance float64, maxIterations int) SphereTraceSettings {
+	return SphereTraceSettings{ClampBetween(relaxation, 1.0, 2.0), minHitDistance, maxIterations}
+}
+
+// Sphere trace a ray through the scene. Rather than taking fixed steps, each step advances the ray by the
+// distance the scene SDF reports, which is the largest step that is guaranteed not to skip over a surface.
+//
+// When over-relaxation is enabled the step is enlarged, and whenever the unbounding spheres of two consecutive
+// steps no longer overlap, the ray falls back to the last safe position and continues with regular sphere tracing.
+//
+// Reference: https://erleuchtet.org/~cupe/permanent/enhanced_sphere_tracing.pdf
+func (s *Scene) SphereTrace(ray Ray, maxDistance float64, settings SphereTraceSettings) (bool, SurfaceHitInfo) {
+	return s.sphereTrace(ray, maxDistance, settings, 1.0)
+}
+
+// Sphere trace a ray that starts inside of a shape until it leaves the shape again. The SDF is negated, which
+// turns the inside of every shape into empty space and the outside into solid space. The normal of the hit still
+// points out of the shape.
+func (s *Scene) SphereTraceInside(ray Ray, maxDistance float64, settings SphereTraceSettings) (bool, SurfaceHitInfo) {
+	return s.sphereTrace(ray, maxDistance, settings, -1.0)
+}
+
+// Sphere trace a ray through the scene with the SDF multiplied by the sign
+func (s *Scene) sphereTrace(ray Ray, maxDistance float64, settings SphereTraceSettings, sign float64) (bool, SurfaceHitInfo) {
+	direction := Normalize(ray.Direction)
+	relaxation := settings.Relaxation
+	distance := 0.0
+	radius := 0.0
+	previousRadius := 0.0
+	stepLength := 0.0
+
+	for step := 1; step <= settings.MaxIterations; step++ {
+		pointInSpace := Add(ray.Origin, MultiplyScalar(direction, distance))
+		radius = sign * s.sceneSDF(pointInSpace)
+
+		// Over-relaxed step overshot, go back to the last position that was known to be safe
+		if relaxation > 1.0 && math.Abs(radius)+previousRadius < stepLength {
+			distance += previousRadius - stepLength
+			stepLength = previousRadius
+			relaxation = 1.0
+			continue
+		}
+
+		if radius < settings.MinHitDistance {
+			// Surface intersection found
+			hitInfo := s.GetIntersectionPointSurfaceHitInfo(pointInSpace, distance)
+			hitInfo.TerminationDistance = radius
+			hitInfo.StepCount = step
+			return true, hitInfo
+		}
+
+		previousRadius = radius
+		stepLength = radius * relaxation
+		distance += stepLength
+
+		if distance >= maxDistance {
+			// Ray left the scene without hitting anything
+			return false, SurfaceHitInfo{RayLength: maxDistance, TerminationDistance: radius, StepCount: step, Termination: TerminationMaxDistance}
+		}
+	}
+
+	// Ran out of iterations while still approaching the surface
+	return false, SurfaceHitInfo{RayLength: distance, TerminationDistance: radius, StepCount: settings.MaxIterations, Termination: TerminationMaxIterations}
+}
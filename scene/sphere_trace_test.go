@@ -0,0 +1,65 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+func TestSphereTraceHit(t *testing.T) {
+	scene := NewScene(func(point Vec3) float64 { return SphereSDF(point, 1.0) })
+
+	// The ray direction is not normalized, the ray length should still be measured in world units
+	didHit, hitInfo := scene.SphereTrace(Ray{Origin: Vec3{Z: -5.0}, Direction: Vec3{Z: 2.0}}, 100.0, NewSphereTraceSettings(1.0, 0.001, 64))
+	if !didHit || hitInfo.Termination != TerminationSurfaceHit {
+		t.Fatalf("SphereTrace failure: expected a surface hit but got %v", hitInfo.Termination)
+	}
+
+	if math.Abs(hitInfo.RayLength-4.0) > 0.001 || hitInfo.StepCount != 2 {
+		t.Fatalf("SphereTrace failure: expected a ray length of 4.0 after 2 steps but got %f after %d steps", hitInfo.RayLength, hitInfo.StepCount)
+	}
+}
+
+func TestSphereTraceTermination(t *testing.T) {
+	// A ray parallel to a plane approaches it by half a unit every step without ever reaching it
+	scene := NewScene(func(point Vec3) float64 { return point.Y })
+	ray := Ray{Origin: Vec3{Y: 0.5}, Direction: Vec3{X: 1.0}}
+	settings := NewSphereTraceSettings(1.0, 0.001, 10)
+
+	samples := []struct {
+		maxDistance float64
+		termination MarchTermination
+		rayLength   float64
+		stepCount   int
+	}{
+		{2.0, TerminationMaxDistance, 2.0, 4},
+		{100.0, TerminationMaxIterations, 5.0, 10},
+	}
+
+	for _, sample := range samples {
+		didHit, hitInfo := scene.SphereTrace(ray, sample.maxDistance, settings)
+
+		if didHit || hitInfo.Termination != sample.termination {
+			t.Fatalf("SphereTrace failure: expected %v but got %v", sample.termination, hitInfo.Termination)
+		}
+
+		if math.Abs(hitInfo.RayLength-sample.rayLength) > 0.001 || hitInfo.StepCount != sample.stepCount {
+			t.Fatalf("SphereTrace failure: expected a ray length of %f after %d steps but got %f after %d steps", sample.rayLength, sample.stepCount, hitInfo.RayLength, hitInfo.StepCount)
+		}
+	}
+}
+
+func TestSphereTraceRelaxationFallback(t *testing.T) {
+	scene := NewScene(func(point Vec3) float64 { return SphereSDF(point, 1.0) })
+
+	// The first over-relaxed step ends up behind the front of the sphere, the trace has to step back to find it
+	didHit, hitInfo := scene.SphereTrace(Ray{Origin: Vec3{Z: -5.0}, Direction: Vec3{Z: 1.0}}, 100.0, NewSphereTraceSettings(1.9, 0.001, 64))
+	if !didHit || math.Abs(hitInfo.Point.Z+1.0) > 0.001 {
+		t.Fatalf("SphereTrace failure: expected to hit the front of the sphere but got %v at %v", hitInfo.Termination, hitInfo.Point)
+	}
+
+	if hitInfo.StepCount != 3 {
+		t.Fatalf("SphereTrace failure: expected the fallback to take 3 steps but got %d", hitInfo.StepCount)
+	}
+}
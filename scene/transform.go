@@ -0,0 +1,99 @@
+package scene
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Affine transformation that places a signed distance function in space. Only uniform scaling is supported
+// as non-uniform scaling would no longer result in a correct distance.
+type Transform struct {
+	Translation Vec3
+	Rotation    Quaternion
+	Scale       float64
+}
+
+// Create a new transform with the following properties:
+//
+//	Translation : position of the shape in space
+//	Rotation    : orientation of the shape as a unit quaternion
+//	Scale       : uniform scale of the shape, kept above zero
+func NewTransform(translation Vec3, rotation Quaternion, scale float64) Transform {
+	return Transform{translation, rotation, validScale(scale)}
+}
+
+// Keep a scale above zero, a collapsed or mirrored space would no longer produce a valid distance
+func validScale(scale float64) float64 {
+	return math.Max(scale, epsilon)
+}
+
+// Create a transform that leaves a shape untouched
+func IdentityTransform() Transform {
+	return Transform{Vec3{}, IdentityQuaternion(), 1.0}
+}
+
+// Move a point from world space into the local space of the transform
+func (t *Transform) ToLocalSpace(point Vec3) Vec3 {
+	localPoint := RotateVec3(Conjugate(t.Rotation), Sub(point, t.Translation))
+	return MultiplyScalar(localPoint, 1.0/t.Scale)
+}
+
+// Move a point from the local space of the transform into world space
+func (t *Transform) ToWorldSpace(point Vec3) Vec3 {
+	return Add(RotateVec3(t.Rotation, MultiplyScalar(point, t.Scale)), t.Translation)
+}
+
+// Apply a transform to a signed distance function. Points are moved into the local space of the shape before
+// evaluating it, after which the distance is multiplied by the scale to correct it for the scaled space.
+func TransformSDF(shape sdf, transform Transform) sdf {
+	transform.Scale = validScale(transform.Scale)
+
+	return func(point Vec3) float64 {
+		return shape(transform.ToLocalSpace(point)) * transform.Scale
+	}
+}
+
+// Move a signed distance function by an offset
+func Translate(shape sdf, offset Vec3) sdf {
+	return func(point Vec3) float64 {
+		return shape(Sub(point, offset))
+	}
+}
+
+// Rotate a signed distance function around the origin using a unit quaternion
+func Rotate(shape sdf, rotation Quaternion) sdf {
+	inverse := Conjugate(rotation)
+
+	return func(point Vec3) float64 {
+		return shape(RotateVec3(inverse, point))
+	}
+}
+
+// Rotate a signed distance function around an axis through the origin by an angle in radians
+func RotateAxisAngle(shape sdf, axis Vec3, angle float64) sdf {
+	return Rotate(shape, QuaternionFromAxisAngle(axis, angle))
+}
+
+// Uniformly scale a signed distance function around the origin, the scale is kept above zero
+func Scale(shape sdf, scale float64) sdf {
+	scale = validScale(scale)
+
+	return func(point Vec3) float64 {
+		return shape(MultiplyScalar(point, 1.0/scale)) * scale
+	}
+}
+
+// Mirror a signed distance function across a plane. Space on the back side of the plane is folded onto the
+// front side, which means the part of the shape in front of the plane appears on both sides of it.
+//
+//	Normal : direction the plane faces, must be normalized
+//	Offset : distance from the origin to the plane along its normal
+func Mirror(shape sdf, normal Vec3, offset float64) sdf {
+	return func(point Vec3) float64 {
+		distanceToPlane := Dot(point, normal) - offset
+		point = Sub(point, MultiplyScalar(normal, 2.0*math.Min(distanceToPlane, 0.0)))
+
+		return shape(point)
+	}
+}
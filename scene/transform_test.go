@@ -0,0 +1,83 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+func unitSphere(point Vec3) float64 {
+	return SphereSDF(point, 1.0)
+}
+
+func TestTranslate(t *testing.T) {
+	verifyDistances(t, "Translate", Translate(unitSphere, Vec3{X: 5.0, Y: 0.0, Z: 0.0}), []distanceSample{
+		{Vec3{X: 5.0, Y: 0.0, Z: 0.0}, -1.0},
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, 4.0},
+	})
+}
+
+func TestScale(t *testing.T) {
+	verifyDistances(t, "Scale", Scale(unitSphere, 2.0), []distanceSample{
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, -2.0},
+		{Vec3{X: 0.0, Y: 5.0, Z: 0.0}, 3.0},
+	})
+}
+
+func TestScaleInvalid(t *testing.T) {
+	for _, scale := range []float64{0.0, -2.0} {
+		shapes := map[string]sdf{
+			"Scale":        Scale(unitSphere, scale),
+			"TransformSDF": TransformSDF(unitSphere, Transform{Vec3{}, IdentityQuaternion(), scale}),
+			"NewTransform": TransformSDF(unitSphere, NewTransform(Vec3{}, IdentityQuaternion(), scale)),
+		}
+
+		for name, shape := range shapes {
+			distance := shape(Vec3{X: 1.0, Y: 0.0, Z: 0.0})
+
+			if math.IsNaN(distance) || math.IsInf(distance, 0) || distance <= 0.0 {
+				t.Fatalf("%s failure: expected a scale of %f to still produce a positive distance but got %f", name, scale, distance)
+			}
+		}
+	}
+}
+
+func TestRotateAxisAngle(t *testing.T) {
+	box := func(point Vec3) float64 { return BoxSDF(point, Vec3{X: 2.0, Y: 1.0, Z: 1.0}) }
+
+	// The long side of the box now runs along the Y axis
+	verifyDistances(t, "RotateAxisAngle", RotateAxisAngle(box, Vec3{X: 0.0, Y: 0.0, Z: 1.0}, math.Pi/2.0), []distanceSample{
+		{Vec3{X: 0.0, Y: 3.0, Z: 0.0}, 1.0},
+		{Vec3{X: 3.0, Y: 0.0, Z: 0.0}, 2.0},
+	})
+}
+
+func TestTransformSDF(t *testing.T) {
+	transform := NewTransform(Vec3{X: 0.0, Y: 0.0, Z: 10.0}, QuaternionFromAxisAngle(Vec3{X: 1.0, Y: 0.0, Z: 0.0}, 1.0), 3.0)
+
+	verifyDistances(t, "TransformSDF", TransformSDF(unitSphere, transform), []distanceSample{
+		{Vec3{X: 0.0, Y: 0.0, Z: 10.0}, -3.0},
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, 7.0},
+	})
+}
+
+func TestTransformRoundTrip(t *testing.T) {
+	transform := NewTransform(Vec3{X: 1.0, Y: 2.0, Z: 3.0}, QuaternionFromAxisAngle(Vec3{X: 1.0, Y: 1.0, Z: 0.0}, 0.5), 2.0)
+	point := Vec3{X: -4.0, Y: 0.5, Z: 2.0}
+	result := transform.ToWorldSpace(transform.ToLocalSpace(point))
+
+	if Length(Sub(result, point)) > tolerance {
+		t.Fatalf("Transform failure: expected %v but got %v", point, result)
+	}
+}
+
+func TestMirror(t *testing.T) {
+	shifted := Translate(unitSphere, Vec3{X: 3.0, Y: 0.0, Z: 0.0})
+
+	verifyDistances(t, "Mirror", Mirror(shifted, Vec3{X: 1.0, Y: 0.0, Z: 0.0}, 0.0), []distanceSample{
+		{Vec3{X: 3.0, Y: 0.0, Z: 0.0}, -1.0},
+		{Vec3{X: -3.0, Y: 0.0, Z: 0.0}, -1.0},
+		{Vec3{X: 0.0, Y: 0.0, Z: 0.0}, 2.0},
+	})
+}
@@ -1,26 +1,52 @@
 package utility
 
-import . "github.com/tntmeijs/gengo/mathematics"
+import (
+	"math"
 
-// Represents an RGBA color
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Represents an RGBA color, the red, green, and blue components are encoded using the sRGB transfer function
 type Color struct {
 	Red, Green, Blue, Alpha uint8
 }
 
-// Normalize the color and return it as a Vec3
+// Convert an sRGB encoded value in [0.0, 1.0] into a linear value
+//
+// Reference: https://en.wikipedia.org/wiki/SRGB#Transfer_function_(%22gamma%22)
+func SrgbToLinear(value float64) float64 {
+	if value <= 0.04045 {
+		return value / 12.92
+	}
+
+	return math.Pow((value+0.055)/1.055, 2.4)
+}
+
+// Convert a linear value in [0.0, 1.0] into an sRGB encoded value
+//
+// Reference: https://en.wikipedia.org/wiki/SRGB#Transfer_function_(%22gamma%22)
+func LinearToSrgb(value float64) float64 {
+	if value <= 0.0031308 {
+		return value * 12.92
+	}
+
+	return 1.055*math.Pow(value, 1.0/2.4) - 0.055
+}
+
+// Normalize the color, decode it from sRGB into linear values, and return it as a Vec3
 func (c *Color) AsNormalizedVec3() Vec3 {
-	x := ClampBetween(float64(c.Red)/255.0, 0.0, 1.0)
-	y := ClampBetween(float64(c.Green)/255.0, 0.0, 1.0)
-	z := ClampBetween(float64(c.Blue)/255.0, 0.0, 1.0)
+	x := SrgbToLinear(ClampBetween(float64(c.Red)/255.0, 0.0, 1.0))
+	y := SrgbToLinear(ClampBetween(float64(c.Green)/255.0, 0.0, 1.0))
+	z := SrgbToLinear(ClampBetween(float64(c.Blue)/255.0, 0.0, 1.0))
 
 	return Vec3{X: x, Y: y, Z: z}
 }
 
-// Convert a normalized Vec3 into a fully opaque color
+// Encode a normalized linear Vec3 using sRGB and convert it into a fully opaque color
 func ColorFromNormalizedVec3(vector Vec3) Color {
-	red := ClampBetween(vector.X*255.0, 0.0, 255.0)
-	green := ClampBetween(vector.Y*255.0, 0.0, 255.0)
-	blue := ClampBetween(vector.Z*255.0, 0.0, 255.0)
+	red := math.Round(LinearToSrgb(ClampBetween(vector.X, 0.0, 1.0)) * 255.0)
+	green := math.Round(LinearToSrgb(ClampBetween(vector.Y, 0.0, 1.0)) * 255.0)
+	blue := math.Round(LinearToSrgb(ClampBetween(vector.Z, 0.0, 1.0)) * 255.0)
 
 	return Color{Red: uint8(red), Green: uint8(green), Blue: uint8(blue), Alpha: 255}
 }
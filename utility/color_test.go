@@ -0,0 +1,36 @@
+package utility
+
+import (
+	"math"
+	"testing"
+)
+
+const epsilon = 0.0001
+
+func TestSrgbTransferFunction(t *testing.T) {
+	if value := LinearToSrgb(0.5); math.Abs(value-0.735357) > epsilon {
+		t.Fatalf("sRGB failure: expected 0.735357 but got %f", value)
+	}
+
+	for _, value := range []float64{0.0, 0.002, 0.01, 0.2, 0.5, 1.0} {
+		if roundTrip := SrgbToLinear(LinearToSrgb(value)); math.Abs(roundTrip-value) > epsilon {
+			t.Fatalf("sRGB failure: expected %f after a round trip but got %f", value, roundTrip)
+		}
+	}
+}
+
+func TestColorRoundTrip(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		color := Color{Red: uint8(i), Green: uint8(255 - i), Blue: uint8(i / 2), Alpha: 255}
+		linear := color.AsNormalizedVec3()
+
+		if roundTrip := ColorFromNormalizedVec3(linear); roundTrip != color {
+			t.Fatalf("Color failure: expected %v after a round trip but got %v", color, roundTrip)
+		}
+	}
+
+	gray := Color{Red: 188, Green: 188, Blue: 188, Alpha: 255}
+	if linear := gray.AsNormalizedVec3(); math.Abs(linear.X-0.502886) > epsilon {
+		t.Fatalf("Color failure: expected sRGB 188 to decode to 0.502886 but got %f", linear.X)
+	}
+}
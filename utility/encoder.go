@@ -0,0 +1,109 @@
+package utility
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// Encodes an image with 8 or 16 bits per channel into a specific file format
+type Encoder interface {
+	Encode(writer io.Writer, image image.Image) error
+}
+
+// Encodes images as PNG, images with 16 bits per channel are stored as 16-bit PNG images
+type PngEncoder struct{}
+
+// Create a new PNG encoder
+func NewPngEncoder() *PngEncoder {
+	return &PngEncoder{}
+}
+
+// Encode the image as PNG
+func (p *PngEncoder) Encode(writer io.Writer, image image.Image) error {
+	return png.Encode(writer, image)
+}
+
+// Encodes images as baseline JPEG, which only supports 8 bits per channel and no alpha
+type JpegEncoder struct {
+	quality int
+}
+
+// Create a new JPEG encoder, the quality ranges from 1 (smallest file) to 100 (best quality)
+func NewJpegEncoder(quality int) *JpegEncoder {
+	if quality < 1 {
+		quality = 1
+	} else if quality > 100 {
+		quality = 100
+	}
+
+	return &JpegEncoder{quality}
+}
+
+// Encode the image as JPEG
+func (j *JpegEncoder) Encode(writer io.Writer, image image.Image) error {
+	return jpeg.Encode(writer, image, &jpeg.Options{Quality: j.quality})
+}
+
+// Check whether the image stores 16 bits per channel
+func is16Bit(image image.Image) bool {
+	switch image.ColorModel() {
+	case color.NRGBA64Model, color.RGBA64Model, color.Gray16Model:
+		return true
+	}
+
+	return false
+}
+
+// Read a pixel as non-premultiplied 16-bit values. The image types a framebuffer is converted into are read
+// directly, which avoids losing precision by premultiplying alpha.
+func nrgba64At(img image.Image, x int, y int) color.NRGBA64 {
+	switch img := img.(type) {
+	case *image.NRGBA:
+		pixel := img.NRGBAAt(x, y)
+		return color.NRGBA64{R: uint16(pixel.R) * 0x101, G: uint16(pixel.G) * 0x101, B: uint16(pixel.B) * 0x101, A: uint16(pixel.A) * 0x101}
+	case *image.NRGBA64:
+		return img.NRGBA64At(x, y)
+	}
+
+	return color.NRGBA64Model.Convert(img.At(x, y)).(color.NRGBA64)
+}
+
+// Store the pixels row by row as interleaved samples of 8 or 16 bits, depending on the bit depth of the image
+func encodeSamples(img image.Image, includeAlpha bool, byteOrder binary.ByteOrder) []byte {
+	bounds := img.Bounds()
+	channels := 3
+	if includeAlpha {
+		channels = 4
+	}
+
+	bytesPerSample := 1
+	if is16Bit(img) {
+		bytesPerSample = 2
+	}
+
+	data := make([]byte, bounds.Dx()*bounds.Dy()*channels*bytesPerSample)
+	position := 0
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pixel := nrgba64At(img, x, y)
+			samples := [4]uint16{pixel.R, pixel.G, pixel.B, pixel.A}
+
+			for _, sample := range samples[:channels] {
+				if bytesPerSample == 2 {
+					byteOrder.PutUint16(data[position:], sample)
+				} else {
+					data[position] = byte(sample >> 8)
+				}
+
+				position += bytesPerSample
+			}
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,122 @@
+package utility
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func testEncoderImage() *image.NRGBA64 {
+	result := image.NewNRGBA64(image.Rect(0, 0, 3, 2))
+	result.SetNRGBA64(0, 0, color.NRGBA64{R: 0x1234, G: 0xffff, B: 0x0000, A: 0x8000})
+	result.SetNRGBA64(2, 1, color.NRGBA64{R: 0xabcd, G: 0x0101, B: 0x7f7f, A: 0xffff})
+	return result
+}
+
+func TestPngEncoder16Bit(t *testing.T) {
+	buffer := bytes.Buffer{}
+	if error := NewPngEncoder().Encode(&buffer, testEncoderImage()); error != nil {
+		t.Fatalf("PNG failure: %s", error.Error())
+	}
+
+	decoded, error := png.Decode(&buffer)
+	if error != nil {
+		t.Fatalf("PNG failure: %s", error.Error())
+	}
+
+	if pixel := decoded.(*image.NRGBA64).NRGBA64At(2, 1); pixel != (color.NRGBA64{R: 0xabcd, G: 0x0101, B: 0x7f7f, A: 0xffff}) {
+		t.Fatalf("PNG failure: expected 16 bits per channel to be preserved but got %v", pixel)
+	}
+}
+
+func TestJpegEncoder(t *testing.T) {
+	buffer := bytes.Buffer{}
+	if error := NewJpegEncoder(150).Encode(&buffer, testEncoderImage()); error != nil {
+		t.Fatalf("JPEG failure: %s", error.Error())
+	}
+
+	decoded, error := jpeg.Decode(&buffer)
+	if error != nil {
+		t.Fatalf("JPEG failure: %s", error.Error())
+	}
+
+	if decoded.Bounds() != image.Rect(0, 0, 3, 2) {
+		t.Fatalf("JPEG failure: expected a 3x2 image but got %v", decoded.Bounds())
+	}
+}
+
+func TestNetpbmEncoders(t *testing.T) {
+	buffer := bytes.Buffer{}
+	if error := NewPpmEncoder().Encode(&buffer, testEncoderImage()); error != nil {
+		t.Fatalf("PPM failure: %s", error.Error())
+	}
+
+	// Samples are stored as big endian 16-bit values without alpha
+	expected := append([]byte("P6\n3 2\n65535\n"), 0x12, 0x34, 0xff, 0xff, 0x00, 0x00)
+	if !bytes.HasPrefix(buffer.Bytes(), expected) || buffer.Len() != len(expected)+5*6 {
+		t.Fatalf("PPM failure: unexpected output %v", buffer.Bytes())
+	}
+
+	// Eight bit images store a single byte per sample
+	eightBit := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	eightBit.SetNRGBA(0, 0, color.NRGBA{R: 10, G: 20, B: 30, A: 40})
+
+	buffer.Reset()
+	if error := NewPamEncoder().Encode(&buffer, eightBit); error != nil {
+		t.Fatalf("PAM failure: %s", error.Error())
+	}
+
+	header := "P7\nWIDTH 1\nHEIGHT 1\nDEPTH 4\nMAXVAL 255\nTUPLTYPE RGB_ALPHA\nENDHDR\n"
+	if !bytes.Equal(buffer.Bytes(), append([]byte(header), 10, 20, 30, 40)) {
+		t.Fatalf("PAM failure: unexpected output %q", buffer.Bytes())
+	}
+}
+
+func TestTiffEncoder(t *testing.T) {
+	buffer := bytes.Buffer{}
+	if error := NewTiffEncoder().Encode(&buffer, testEncoderImage()); error != nil {
+		t.Fatalf("TIFF failure: %s", error.Error())
+	}
+
+	data := buffer.Bytes()
+	if !bytes.HasPrefix(data, []byte{'I', 'I', 42, 0}) {
+		t.Fatalf("TIFF failure: unexpected header %v", data[:4])
+	}
+
+	// Collect the values of all tags that fit in the image file directory
+	directory := binary.LittleEndian.Uint32(data[4:])
+	entryCount := int(binary.LittleEndian.Uint16(data[directory:]))
+	tags := map[uint16]uint32{}
+
+	for i := 0; i < entryCount; i++ {
+		entry := data[int(directory)+2+i*12:]
+		tag, count := binary.LittleEndian.Uint16(entry), binary.LittleEndian.Uint32(entry[4:])
+
+		if binary.LittleEndian.Uint16(entry[2:]) == tiffShort && count == 1 {
+			tags[tag] = uint32(binary.LittleEndian.Uint16(entry[8:]))
+		} else {
+			tags[tag] = binary.LittleEndian.Uint32(entry[8:])
+		}
+	}
+
+	if tags[256] != 3 || tags[257] != 2 || tags[277] != 4 || tags[338] != 2 {
+		t.Fatalf("TIFF failure: unexpected tags %v", tags)
+	}
+
+	if bitsPerSample := binary.LittleEndian.Uint16(data[tags[258]:]); bitsPerSample != 16 {
+		t.Fatalf("TIFF failure: expected 16 bits per sample but got %d", bitsPerSample)
+	}
+
+	pixels := data[tags[273]:]
+	if uint32(len(pixels)) != tags[279] || tags[279] != 3*2*4*2 {
+		t.Fatalf("TIFF failure: expected %d bytes of pixel data but got %d", 3*2*4*2, len(pixels))
+	}
+
+	if red, alpha := binary.LittleEndian.Uint16(pixels), binary.LittleEndian.Uint16(pixels[6:]); red != 0x1234 || alpha != 0x8000 {
+		t.Fatalf("TIFF failure: expected red 0x1234 and alpha 0x8000 but got %#04x and %#04x", red, alpha)
+	}
+}
@@ -0,0 +1,115 @@
+package utility
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Image that stores linear colors and alpha as floating point values. Colors are not limited to [0.0, 1.0], which
+// allows for high dynamic range output, accumulating samples, and post-processing without losing precision.
+type Framebuffer struct {
+	width, height int
+	colors        []Vec3
+	alphas        []float64
+}
+
+// Create a new framebuffer where every pixel is transparent black
+func NewFramebuffer(width int, height int) Framebuffer {
+	return Framebuffer{width, height, make([]Vec3, width*height), make([]float64, width*height)}
+}
+
+// Get the width of the framebuffer in pixels
+func (f *Framebuffer) GetWidth() int {
+	return f.width
+}
+
+// Get the height of the framebuffer in pixels
+func (f *Framebuffer) GetHeight() int {
+	return f.height
+}
+
+// Get the linear color of a pixel
+func (f *Framebuffer) GetPixel(x int, y int) Vec3 {
+	return f.colors[y*f.width+x]
+}
+
+// Get the alpha of a pixel
+func (f *Framebuffer) GetAlpha(x int, y int) float64 {
+	return f.alphas[y*f.width+x]
+}
+
+// Set the linear color and alpha of a pixel
+func (f *Framebuffer) SetPixel(x int, y int, color Vec3, alpha float64) {
+	f.colors[y*f.width+x] = color
+	f.alphas[y*f.width+x] = alpha
+}
+
+// Add a linear color and alpha to a pixel, which makes it possible to accumulate samples
+func (f *Framebuffer) AddPixel(x int, y int, color Vec3, alpha float64) {
+	f.colors[y*f.width+x].Add(color)
+	f.alphas[y*f.width+x] += alpha
+}
+
+// Copy all pixels of another framebuffer into this framebuffer, the top left corner of the other framebuffer
+// ends up at the specified position. Pixels that fall outside of this framebuffer are ignored.
+func (f *Framebuffer) CopyFrom(other *Framebuffer, offsetX int, offsetY int) {
+	for y := 0; y < other.height; y++ {
+		for x := 0; x < other.width; x++ {
+			if x+offsetX < 0 || x+offsetX >= f.width || y+offsetY < 0 || y+offsetY >= f.height {
+				continue
+			}
+
+			f.SetPixel(x+offsetX, y+offsetY, other.GetPixel(x, y), other.GetAlpha(x, y))
+		}
+	}
+}
+
+// Convert the framebuffer into an image with 16 bits per channel when the bit depth is 16 and 8 bits per channel
+// otherwise. The output transform maps the colors into [0.0, 1.0] and encodes them, alpha is stored linearly without
+// premultiplying the colors.
+func (f *Framebuffer) ToImage(transform OutputTransform, bitDepth int) image.Image {
+	if bitDepth == 16 {
+		return f.toNrgba64(transform)
+	}
+
+	return f.toNrgba(transform)
+}
+
+// Convert the framebuffer into an 8-bit image
+func (f *Framebuffer) toNrgba(transform OutputTransform) *image.NRGBA {
+	result := image.NewNRGBA(image.Rect(0, 0, f.width, f.height))
+	encode := func(value float64) uint8 {
+		return uint8(math.Round(transform.Encode(ClampBetween(value, 0.0, 1.0)) * 255.0))
+	}
+
+	for y := 0; y < f.height; y++ {
+		for x := 0; x < f.width; x++ {
+			pixel := transform.Apply(f.GetPixel(x, y))
+			alpha := uint8(ClampBetween(f.GetAlpha(x, y)*255.0, 0.0, 255.0))
+			result.SetNRGBA(x, y, color.NRGBA{R: encode(pixel.X), G: encode(pixel.Y), B: encode(pixel.Z), A: alpha})
+		}
+	}
+
+	return result
+}
+
+// Convert the framebuffer into a 16-bit image
+func (f *Framebuffer) toNrgba64(transform OutputTransform) *image.NRGBA64 {
+	result := image.NewNRGBA64(image.Rect(0, 0, f.width, f.height))
+	encode := func(value float64) uint16 {
+		return uint16(math.Round(transform.Encode(ClampBetween(value, 0.0, 1.0)) * 65535.0))
+	}
+
+	for y := 0; y < f.height; y++ {
+		for x := 0; x < f.width; x++ {
+			pixel := transform.Apply(f.GetPixel(x, y))
+			alpha := uint16(ClampBetween(f.GetAlpha(x, y)*65535.0, 0.0, 65535.0))
+			result.SetNRGBA64(x, y, color.NRGBA64{R: encode(pixel.X), G: encode(pixel.Y), B: encode(pixel.Z), A: alpha})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,75 @@
+package utility
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+func TestFramebufferPixels(t *testing.T) {
+	framebuffer := NewFramebuffer(2, 2)
+	if framebuffer.GetPixel(1, 1) != (Vec3{}) || framebuffer.GetAlpha(1, 1) != 0.0 {
+		t.Fatalf("Framebuffer failure: expected a new framebuffer to be transparent black")
+	}
+
+	framebuffer.SetPixel(1, 0, Vec3{X: 2.5, Y: 0.5, Z: -1.0}, 1.0)
+	framebuffer.AddPixel(1, 0, Vec3{X: 0.5, Y: 0.5, Z: 1.0}, 1.0)
+
+	if pixel := framebuffer.GetPixel(1, 0); pixel != (Vec3{X: 3.0, Y: 1.0, Z: 0.0}) {
+		t.Fatalf("Framebuffer failure: expected {3 1 0} but got %v", pixel)
+	}
+
+	if alpha := framebuffer.GetAlpha(1, 0); alpha != 2.0 {
+		t.Fatalf("Framebuffer failure: expected an alpha of 2.0 but got %f", alpha)
+	}
+}
+
+func TestFramebufferCopyFrom(t *testing.T) {
+	framebuffer := NewFramebuffer(3, 3)
+	chunk := NewFramebuffer(3, 2)
+	chunk.SetPixel(0, 0, Vec3{X: 1.0}, 1.0)
+	chunk.SetPixel(2, 1, Vec3{Z: 1.0}, 1.0)
+
+	// The second row of the chunk falls outside of the framebuffer
+	framebuffer.CopyFrom(&chunk, 0, 2)
+
+	if pixel := framebuffer.GetPixel(0, 2); pixel != (Vec3{X: 1.0}) {
+		t.Fatalf("Framebuffer failure: expected {1 0 0} but got %v", pixel)
+	}
+}
+
+func TestFramebufferToImage(t *testing.T) {
+	framebuffer := NewFramebuffer(1, 1)
+	framebuffer.SetPixel(0, 0, Vec3{X: 2.0, Y: 0.25, Z: -1.0}, 0.5)
+	transform := NewOutputTransform(-1.0, NewClampToneMapper())
+
+	// The exposure halves the colors before they are clamped and encoded using sRGB, alpha is stored linearly
+	pixel := framebuffer.ToImage(transform, 8).(*image.NRGBA).NRGBAAt(0, 0)
+	if pixel != (color.NRGBA{R: 255, G: 99, B: 0, A: 127}) {
+		t.Fatalf("Framebuffer failure: expected {255 99 0 127} but got %v", pixel)
+	}
+
+	wide := framebuffer.ToImage(transform, 16).(*image.NRGBA64).NRGBA64At(0, 0)
+	if wide.R != 65535 || wide.G>>8 != 99 || wide.B != 0 || wide.A != 32767 {
+		t.Fatalf("Framebuffer failure: expected {65535 ~25443 0 32767} but got %v", wide)
+	}
+}
+
+func TestFramebufferToLinearImage(t *testing.T) {
+	framebuffer := NewFramebuffer(1, 1)
+	framebuffer.SetPixel(0, 0, Vec3{X: 0.5, Y: 1.0, Z: 2.0}, 1.0)
+	transform := NewLinearOutputTransform()
+
+	// Data such as ambient occlusion is stored as is, without the sRGB encoding
+	pixel := framebuffer.ToImage(transform, 8).(*image.NRGBA).NRGBAAt(0, 0)
+	if pixel != (color.NRGBA{R: 128, G: 255, B: 255, A: 255}) {
+		t.Fatalf("Framebuffer failure: expected {128 255 255 255} but got %v", pixel)
+	}
+
+	wide := framebuffer.ToImage(transform, 16).(*image.NRGBA64).NRGBA64At(0, 0)
+	if wide.R != 32768 || wide.G != 65535 {
+		t.Fatalf("Framebuffer failure: expected {32768 65535 65535 65535} but got %v", wide)
+	}
+}
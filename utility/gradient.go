@@ -0,0 +1,65 @@
+package utility
+
+import (
+	"sort"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// A single color in a gradient, the color is stored as a normalized Vec3
+type ColorStop struct {
+	Position float64
+	Color    Vec3
+}
+
+// Color gradient that linearly interpolates between color stops
+type Gradient struct {
+	stops []ColorStop
+}
+
+// Create a new color stop from an RGBA color
+func NewColorStop(position float64, color Color) ColorStop {
+	return ColorStop{position, color.AsNormalizedVec3()}
+}
+
+// Create a new gradient, the color stops do not have to be sorted
+func NewGradient(stops ...ColorStop) Gradient {
+	sorted := make([]ColorStop, len(stops))
+	copy(sorted, stops)
+
+	sort.SliceStable(sorted, func(i int, j int) bool {
+		return sorted[i].Position < sorted[j].Position
+	})
+
+	return Gradient{sorted}
+}
+
+// Sample the gradient at the specified position, positions outside of the gradient return the color of the
+// closest color stop
+func (g *Gradient) Sample(position float64) Vec3 {
+	if len(g.stops) == 0 {
+		return Vec3{}
+	}
+
+	if position <= g.stops[0].Position {
+		return g.stops[0].Color
+	}
+
+	for i := 1; i < len(g.stops); i++ {
+		previous := g.stops[i-1]
+		next := g.stops[i]
+
+		if position <= next.Position {
+			// Stops that share a position form a hard edge
+			width := next.Position - previous.Position
+			if width <= 0.0 {
+				return next.Color
+			}
+
+			t := (position - previous.Position) / width
+			return Add(MultiplyScalar(previous.Color, 1.0-t), MultiplyScalar(next.Color, t))
+		}
+	}
+
+	return g.stops[len(g.stops)-1].Color
+}
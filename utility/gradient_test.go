@@ -0,0 +1,61 @@
+package utility
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+func TestGradientSample(t *testing.T) {
+	// The stops are not sorted on purpose
+	gradient := NewGradient(
+		NewColorStop(1.0, Color{Red: 255, Green: 255, Blue: 255, Alpha: 255}),
+		NewColorStop(0.0, Color{Alpha: 255}),
+		NewColorStop(0.5, Color{Red: 255, Alpha: 255}),
+	)
+
+	samples := []struct {
+		position float64
+		color    Vec3
+	}{
+		{-1.0, Vec3{}},
+		{0.0, Vec3{}},
+		{0.25, Vec3{X: 0.5}},
+		{0.5, Vec3{X: 1.0}},
+		{0.75, Vec3{X: 1.0, Y: 0.5, Z: 0.5}},
+		{1.0, Vec3{X: 1.0, Y: 1.0, Z: 1.0}},
+		{2.0, Vec3{X: 1.0, Y: 1.0, Z: 1.0}},
+	}
+
+	for _, sample := range samples {
+		color := gradient.Sample(sample.position)
+
+		if Length(Sub(color, sample.color)) > 0.0001 {
+			t.Fatalf("Gradient failure: expected %v at %f but got %v", sample.color, sample.position, color)
+		}
+	}
+}
+
+func TestGradientHardEdge(t *testing.T) {
+	black := Color{Alpha: 255}
+	white := Color{Red: 255, Green: 255, Blue: 255, Alpha: 255}
+	gradient := NewGradient(NewColorStop(0.0, black), NewColorStop(0.5, black), NewColorStop(0.5, white), NewColorStop(1.0, white))
+
+	for _, position := range []float64{0.25, 0.5, 0.50001, 0.75} {
+		color := gradient.Sample(position)
+		expected := 0.0
+		if position > 0.5 {
+			expected = 1.0
+		}
+
+		if math.IsNaN(color.X) || color.X != expected {
+			t.Fatalf("Gradient failure: expected %f at %f but got %v", expected, position, color)
+		}
+	}
+
+	empty := NewGradient()
+	if color := empty.Sample(0.5); color != (Vec3{}) {
+		t.Fatalf("Gradient failure: expected an empty gradient to be black but got %v", color)
+	}
+}
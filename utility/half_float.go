@@ -0,0 +1,77 @@
+package utility
+
+import "math"
+
+// Convert a 32-bit float into a 16-bit half float, rounding to the nearest representable value. Values that are
+// too large become infinity and values that are too small become zero.
+//
+// Reference: https://en.wikipedia.org/wiki/Half-precision_floating-point_format
+func float32ToHalf(value float32) uint16 {
+	bits := math.Float32bits(value)
+	sign := uint16(bits>>16) & 0x8000
+	exponent := int32((bits>>23)&0xff) - 127 + 15
+	mantissa := bits & 0x7fffff
+
+	// Infinity and NaN keep their meaning
+	if (bits>>23)&0xff == 0xff {
+		if mantissa != 0 {
+			return sign | 0x7e00
+		}
+
+		return sign | 0x7c00
+	}
+
+	if exponent >= 31 {
+		return sign | 0x7c00
+	}
+
+	// Values below the smallest normal half float become subnormal, the implicit leading bit becomes explicit
+	if exponent <= 0 {
+		if exponent < -10 {
+			return sign
+		}
+
+		mantissa |= 0x800000
+		shift := uint32(14 - exponent)
+		half := uint16(mantissa >> shift)
+		remainder := mantissa & (1<<shift - 1)
+		halfway := uint32(1) << (shift - 1)
+
+		if remainder > halfway || (remainder == halfway && half&1 == 1) {
+			half++
+		}
+
+		return sign | half
+	}
+
+	// Rounding up may carry into the exponent, which correctly results in the next power of two or infinity
+	half := sign | uint16(exponent)<<10 | uint16(mantissa>>13)
+	remainder := mantissa & 0x1fff
+
+	if remainder > 0x1000 || (remainder == 0x1000 && half&1 == 1) {
+		half++
+	}
+
+	return half
+}
+
+// Convert a 16-bit half float into a 32-bit float, every half float can be represented exactly
+func halfToFloat32(half uint16) float32 {
+	sign := uint32(half&0x8000) << 16
+	exponent := uint32(half>>10) & 0x1f
+	mantissa := uint32(half & 0x3ff)
+
+	switch exponent {
+	case 0:
+		value := float32(math.Ldexp(float64(mantissa), -24))
+		if sign != 0 {
+			return -value
+		}
+
+		return value
+	case 31:
+		return math.Float32frombits(sign | 0x7f800000 | mantissa<<13)
+	default:
+		return math.Float32frombits(sign | (exponent-15+127)<<23 | mantissa<<13)
+	}
+}
@@ -0,0 +1,129 @@
+package utility
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Writes a framebuffer in a specific image format
+type ImageWriter interface {
+	Write(writer io.Writer, framebuffer *Framebuffer) error
+}
+
+// Writes framebuffers in a low dynamic range format, the output transform maps the colors into [0.0, 1.0] before
+// they are encoded using sRGB, or stored as is for linear transforms, with 8 or 16 bits per channel
+type LdrWriter struct {
+	encoder   Encoder
+	transform OutputTransform
+	bitDepth  int
+}
+
+// Create a new low dynamic range image writer
+//
+//	encoder   : Image format the colors are encoded in
+//	transform : Maps the linear colors into [0.0, 1.0]
+//	bitDepth  : Bits per channel, 16 or otherwise 8
+func NewLdrWriter(encoder Encoder, transform OutputTransform, bitDepth int) *LdrWriter {
+	return &LdrWriter{encoder, transform, bitDepth}
+}
+
+// Write the framebuffer using the encoder
+func (l *LdrWriter) Write(writer io.Writer, framebuffer *Framebuffer) error {
+	return l.encoder.Encode(writer, framebuffer.ToImage(l.transform, l.bitDepth))
+}
+
+// Writes framebuffers as OpenEXR images with red, green, blue, and alpha channels
+type ExrWriter struct {
+	pixelType   ExrPixelType
+	compression ExrCompression
+}
+
+// Create a new OpenEXR image writer
+func NewExrWriter(pixelType ExrPixelType, compression ExrCompression) *ExrWriter {
+	return &ExrWriter{pixelType, compression}
+}
+
+// Write the framebuffer as an OpenEXR image
+func (e *ExrWriter) Write(writer io.Writer, framebuffer *Framebuffer) error {
+	image := NewExrImage(framebuffer.GetWidth(), framebuffer.GetHeight())
+	if error := image.AddFramebuffer("", framebuffer, e.pixelType); error != nil {
+		return error
+	}
+
+	return image.Write(writer, e.compression)
+}
+
+// Select an image writer based on the extension of the file name. The output transform and bit depth are only
+// used by formats that cannot store high dynamic range colors, the other formats store the linear colors as is.
+// Bit depths other than 8 and 16 result in an error.
+//
+//	.png          : sRGB PNG
+//	.jpg / .jpeg  : sRGB JPEG with a quality of 90, always 8 bits per channel
+//	.tif / .tiff  : sRGB TIFF
+//	.ppm          : sRGB Portable PixMap without alpha
+//	.pam          : sRGB Portable Arbitrary Map
+//	.hdr / .pic   : Radiance RGBE
+//	.pfm          : Portable FloatMap
+//	.exr          : OpenEXR with half floats and ZIP compression
+func NewImageWriterForFile(fileName string, transform OutputTransform, bitDepth int) (ImageWriter, error) {
+	if bitDepth != 8 && bitDepth != 16 {
+		return nil, errors.New(fmt.Sprintf("Unsupported bit depth %d, only 8 and 16 bits per channel are supported", bitDepth))
+	}
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".png":
+		return NewLdrWriter(NewPngEncoder(), transform, bitDepth), nil
+	case ".jpg", ".jpeg":
+		return NewLdrWriter(NewJpegEncoder(90), transform, 8), nil
+	case ".tif", ".tiff":
+		return NewLdrWriter(NewTiffEncoder(), transform, bitDepth), nil
+	case ".ppm":
+		return NewLdrWriter(NewPpmEncoder(), transform, bitDepth), nil
+	case ".pam":
+		return NewLdrWriter(NewPamEncoder(), transform, bitDepth), nil
+	case ".hdr", ".pic":
+		return NewRadianceHdrWriter(), nil
+	case ".pfm":
+		return NewPfmWriter(), nil
+	case ".exr":
+		return NewExrWriter(ExrPixelHalf, ExrCompressionZip), nil
+	}
+
+	return nil, errors.New(fmt.Sprintf("No image writer for %s", fileName))
+}
+
+// Check whether the image format of a file stores linear colors as is, rather than mapping them into [0.0, 1.0]
+func IsHighDynamicRangeFile(fileName string) bool {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".hdr", ".pic", ".pfm", ".exr":
+		return true
+	}
+
+	return false
+}
+
+// Write the framebuffer to a file on disk, the image format is based on the extension of the file name
+func WriteImageFile(fileName string, framebuffer *Framebuffer, transform OutputTransform, bitDepth int) error {
+	writer, error := NewImageWriterForFile(fileName, transform, bitDepth)
+	if error != nil {
+		return error
+	}
+
+	file, error := os.Create(fileName)
+
+	// Unable to create a new file on disk
+	if error != nil {
+		return errors.New(fmt.Sprintf("Unable to write %s to disk: %s", fileName, error.Error()))
+	}
+
+	if error := writer.Write(file, framebuffer); error != nil {
+		file.Close()
+		return error
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,50 @@
+package utility
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewImageWriterForFile(t *testing.T) {
+	transform := NewOutputTransform(0.0, NewClampToneMapper())
+
+	cases := map[string]string{
+		"output.png":     "*utility.LdrWriter",
+		"output.JPEG":    "*utility.LdrWriter",
+		"output.tif":     "*utility.LdrWriter",
+		"output.pam":     "*utility.LdrWriter",
+		"output.HDR":     "*utility.RadianceHdrWriter",
+		"output.pic":     "*utility.RadianceHdrWriter",
+		"render/out.pfm": "*utility.PfmWriter",
+		"output.exr":     "*utility.ExrWriter",
+	}
+
+	for fileName, expected := range cases {
+		writer, error := NewImageWriterForFile(fileName, transform, 8)
+		if error != nil {
+			t.Fatalf("Image writer failure: %s", error.Error())
+		}
+
+		if name := fmt.Sprintf("%T", writer); name != expected {
+			t.Fatalf("Image writer failure: expected %s for %s but got %s", expected, fileName, name)
+		}
+	}
+
+	if _, error := NewImageWriterForFile("output.gif", transform, 8); error == nil {
+		t.Fatalf("Image writer failure: expected an error for an unsupported extension")
+	}
+
+	for _, bitDepth := range []int{0, 12, 32} {
+		if _, error := NewImageWriterForFile("output.png", transform, bitDepth); error == nil {
+			t.Fatalf("Image writer failure: expected an error for a bit depth of %d", bitDepth)
+		}
+	}
+}
+
+func TestIsHighDynamicRangeFile(t *testing.T) {
+	for fileName, expected := range map[string]bool{"depth.exr": true, "depth.PFM": true, "depth.png": false, "depth": false} {
+		if result := IsHighDynamicRangeFile(fileName); result != expected {
+			t.Fatalf("Image writer failure: expected %t for %s but got %t", expected, fileName, result)
+		}
+	}
+}
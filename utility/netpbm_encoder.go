@@ -0,0 +1,62 @@
+package utility
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+)
+
+// Encodes images as binary Portable PixMap (P6), alpha is discarded
+//
+// Reference: https://netpbm.sourceforge.net/doc/ppm.html
+type PpmEncoder struct{}
+
+// Create a new PPM encoder
+func NewPpmEncoder() *PpmEncoder {
+	return &PpmEncoder{}
+}
+
+// Encode the image as PPM, images with 16 bits per channel use a maximum value of 65535
+func (p *PpmEncoder) Encode(writer io.Writer, image image.Image) error {
+	bounds := image.Bounds()
+
+	if _, error := fmt.Fprintf(writer, "P6\n%d %d\n%d\n", bounds.Dx(), bounds.Dy(), netpbmMaximumValue(image)); error != nil {
+		return error
+	}
+
+	_, error := writer.Write(encodeSamples(image, false, binary.BigEndian))
+	return error
+}
+
+// Encodes images as Portable Arbitrary Map (P7) with red, green, blue, and alpha channels
+//
+// Reference: https://netpbm.sourceforge.net/doc/pam.html
+type PamEncoder struct{}
+
+// Create a new PAM encoder
+func NewPamEncoder() *PamEncoder {
+	return &PamEncoder{}
+}
+
+// Encode the image as PAM, images with 16 bits per channel use a maximum value of 65535
+func (p *PamEncoder) Encode(writer io.Writer, image image.Image) error {
+	bounds := image.Bounds()
+	header := "P7\nWIDTH %d\nHEIGHT %d\nDEPTH 4\nMAXVAL %d\nTUPLTYPE RGB_ALPHA\nENDHDR\n"
+
+	if _, error := fmt.Fprintf(writer, header, bounds.Dx(), bounds.Dy(), netpbmMaximumValue(image)); error != nil {
+		return error
+	}
+
+	_, error := writer.Write(encodeSamples(image, true, binary.BigEndian))
+	return error
+}
+
+// Largest sample value, Netpbm formats store samples larger than 255 as two big endian bytes
+func netpbmMaximumValue(image image.Image) int {
+	if is16Bit(image) {
+		return 65535
+	}
+
+	return 255
+}
@@ -0,0 +1,672 @@
+package utility
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Data type used to store the values of an OpenEXR channel
+type ExrPixelType int32
+
+const (
+	// 32-bit unsigned integer
+	ExrPixelUint ExrPixelType = iota
+
+	// 16-bit floating point value
+	ExrPixelHalf
+
+	// 32-bit floating point value
+	ExrPixelFloat
+)
+
+// Compression applied to the pixel data of an OpenEXR file
+type ExrCompression uint8
+
+const (
+	// Pixel data is stored as is
+	ExrCompressionNone ExrCompression = 0
+
+	// Each scanline is compressed separately using zlib
+	ExrCompressionZips ExrCompression = 2
+
+	// Blocks of 16 scanlines are compressed together using zlib
+	ExrCompressionZip ExrCompression = 3
+)
+
+const exrMagicNumber = 20000630
+const exrVersion = 2
+
+// Flag in the version field for files with attribute or channel names longer than 31 bytes, names can be up to
+// 255 bytes long when it is set
+const exrLongNamesFlag = 0x400
+
+// Flags in the version field for features that are not supported
+const exrUnsupportedFlags = 0x200 | 0x800 | 0x1000
+
+// A single named channel of an OpenEXR image, the values are stored row by row
+type ExrChannel struct {
+	Name      string
+	PixelType ExrPixelType
+	Values    []float64
+}
+
+// Image that can be stored as a single-part scanline OpenEXR file. Every channel has its own name and data type,
+// which makes it possible to store the beauty render alongside any number of arbitrary output variables.
+//
+// Reference: https://openexr.com/en/latest/OpenEXRFileLayout.html
+type ExrImage struct {
+	width, height int
+	channels      []ExrChannel
+}
+
+// Create a new OpenEXR image without any channels
+func NewExrImage(width int, height int) ExrImage {
+	return ExrImage{width: width, height: height}
+}
+
+// Get the width of the image in pixels
+func (e *ExrImage) GetWidth() int {
+	return e.width
+}
+
+// Get the height of the image in pixels
+func (e *ExrImage) GetHeight() int {
+	return e.height
+}
+
+// Add a channel to the image, there has to be a value for every pixel and channel names have to be unique and at
+// most 255 bytes long
+func (e *ExrImage) AddChannel(name string, pixelType ExrPixelType, values []float64) error {
+	if name == "" || len(name) > 255 || strings.ContainsRune(name, 0) {
+		return errors.New(fmt.Sprintf("Invalid channel name %q", name))
+	}
+
+	if len(values) != e.width*e.height {
+		return errors.New(fmt.Sprintf("Channel %s has %d values but the image has %d pixels", name, len(values), e.width*e.height))
+	}
+
+	if _, exists := e.GetChannel(name); exists {
+		return errors.New(fmt.Sprintf("Channel %s already exists", name))
+	}
+
+	e.channels = append(e.channels, ExrChannel{name, pixelType, values})
+	return nil
+}
+
+// Add the red, green, blue, and alpha values of a framebuffer as channels. The channel names default to R, G,
+// B, and A, fewer names can be specified to only add the first channels. When a layer is specified the channel
+// names are prefixed with it, like "layer.R".
+func (e *ExrImage) AddFramebuffer(layer string, framebuffer *Framebuffer, pixelType ExrPixelType, channelNames ...string) error {
+	if framebuffer.GetWidth() != e.width || framebuffer.GetHeight() != e.height {
+		return errors.New(fmt.Sprintf("Framebuffer of %dx%d pixels does not match the image", framebuffer.GetWidth(), framebuffer.GetHeight()))
+	}
+
+	if len(channelNames) == 0 {
+		channelNames = []string{"R", "G", "B", "A"}
+	}
+
+	if len(channelNames) > 4 {
+		return errors.New("A framebuffer has at most four channels")
+	}
+
+	values := make([][]float64, len(channelNames))
+	for i := range values {
+		values[i] = make([]float64, 0, e.width*e.height)
+	}
+
+	for y := 0; y < e.height; y++ {
+		for x := 0; x < e.width; x++ {
+			color := framebuffer.GetPixel(x, y)
+			pixel := [4]float64{color.X, color.Y, color.Z, framebuffer.GetAlpha(x, y)}
+
+			for i := range values {
+				values[i] = append(values[i], pixel[i])
+			}
+		}
+	}
+
+	for i, name := range channelNames {
+		if layer != "" {
+			name = layer + "." + name
+		}
+
+		if error := e.AddChannel(name, pixelType, values[i]); error != nil {
+			return error
+		}
+	}
+
+	return nil
+}
+
+// Get the channel with the specified name
+func (e *ExrImage) GetChannel(name string) (ExrChannel, bool) {
+	for _, channel := range e.channels {
+		if channel.Name == name {
+			return channel, true
+		}
+	}
+
+	return ExrChannel{}, false
+}
+
+// Get the names of all channels in the image
+func (e *ExrImage) GetChannelNames() []string {
+	names := make([]string, len(e.channels))
+	for i, channel := range e.channels {
+		names[i] = channel.Name
+	}
+
+	return names
+}
+
+// Number of scanlines stored together in a single chunk
+func exrLinesPerChunk(compression ExrCompression) int {
+	if compression == ExrCompressionZip {
+		return 16
+	}
+
+	return 1
+}
+
+// Number of bytes a single value of the pixel type occupies
+func exrPixelSize(pixelType ExrPixelType) int {
+	if pixelType == ExrPixelHalf {
+		return 2
+	}
+
+	return 4
+}
+
+// Write the image to a file on disk
+func (e *ExrImage) WriteExrToFile(fileName string, compression ExrCompression) error {
+	file, error := os.Create(fileName)
+
+	// Unable to create a new file on disk
+	if error != nil {
+		return errors.New(fmt.Sprintf("Unable to write %s to disk: %s", fileName, error.Error()))
+	}
+
+	if error := e.Write(file, compression); error != nil {
+		file.Close()
+		return error
+	}
+
+	return file.Close()
+}
+
+// Write the image as an OpenEXR file
+func (e *ExrImage) Write(writer io.Writer, compression ExrCompression) error {
+	if compression != ExrCompressionNone && compression != ExrCompressionZips && compression != ExrCompressionZip {
+		return errors.New(fmt.Sprintf("Unsupported compression %d", compression))
+	}
+
+	// Channels are always stored in alphabetical order
+	channels := make([]ExrChannel, len(e.channels))
+	copy(channels, e.channels)
+	sort.Slice(channels, func(i int, j int) bool {
+		return channels[i].Name < channels[j].Name
+	})
+
+	version := uint32(exrVersion)
+	channelList := bytes.Buffer{}
+
+	for _, channel := range channels {
+		if len(channel.Name) > 31 {
+			version |= exrLongNamesFlag
+		}
+
+		channelList.WriteString(channel.Name)
+		channelList.WriteByte(0)
+		binary.Write(&channelList, binary.LittleEndian, channel.PixelType)
+		channelList.Write([]byte{0, 0, 0, 0})
+		binary.Write(&channelList, binary.LittleEndian, []int32{1, 1})
+	}
+	channelList.WriteByte(0)
+
+	window := bytes.Buffer{}
+	binary.Write(&window, binary.LittleEndian, []int32{0, 0, int32(e.width - 1), int32(e.height - 1)})
+
+	header := bytes.Buffer{}
+	binary.Write(&header, binary.LittleEndian, []uint32{exrMagicNumber, version})
+	writeExrAttribute(&header, "channels", "chlist", channelList.Bytes())
+	writeExrAttribute(&header, "compression", "compression", []byte{byte(compression)})
+	writeExrAttribute(&header, "dataWindow", "box2i", window.Bytes())
+	writeExrAttribute(&header, "displayWindow", "box2i", window.Bytes())
+	writeExrAttribute(&header, "lineOrder", "lineOrder", []byte{0})
+	writeExrAttribute(&header, "pixelAspectRatio", "float", float32Bytes(1.0))
+	writeExrAttribute(&header, "screenWindowCenter", "v2f", append(float32Bytes(0.0), float32Bytes(0.0)...))
+	writeExrAttribute(&header, "screenWindowWidth", "float", float32Bytes(1.0))
+	header.WriteByte(0)
+
+	// The offset table points to the start of every chunk in the file
+	linesPerChunk := exrLinesPerChunk(compression)
+	chunkCount := (e.height + linesPerChunk - 1) / linesPerChunk
+	offsets := make([]uint64, chunkCount)
+	chunks := bytes.Buffer{}
+	firstChunk := uint64(header.Len() + 8*chunkCount)
+
+	for i := 0; i < chunkCount; i++ {
+		startRow := i * linesPerChunk
+		endRow := int(math.Min(float64(startRow+linesPerChunk), float64(e.height)))
+		data := e.encodeRows(channels, startRow, endRow)
+
+		if compression != ExrCompressionNone {
+			compressed, error := zipCompress(data)
+			if error != nil {
+				return error
+			}
+
+			data = compressed
+		}
+
+		offsets[i] = firstChunk + uint64(chunks.Len())
+		binary.Write(&chunks, binary.LittleEndian, []int32{int32(startRow), int32(len(data))})
+		chunks.Write(data)
+	}
+
+	if _, error := writer.Write(header.Bytes()); error != nil {
+		return error
+	}
+
+	if error := binary.Write(writer, binary.LittleEndian, offsets); error != nil {
+		return error
+	}
+
+	_, error := writer.Write(chunks.Bytes())
+	return error
+}
+
+// Write a single header attribute
+func writeExrAttribute(header *bytes.Buffer, name string, attributeType string, value []byte) {
+	header.WriteString(name)
+	header.WriteByte(0)
+	header.WriteString(attributeType)
+	header.WriteByte(0)
+	binary.Write(header, binary.LittleEndian, int32(len(value)))
+	header.Write(value)
+}
+
+// Little endian bytes of a 32-bit float
+func float32Bytes(value float32) []byte {
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, math.Float32bits(value))
+	return data
+}
+
+// Store the values of a range of rows, within each row the values are grouped per channel
+func (e *ExrImage) encodeRows(channels []ExrChannel, startRow int, endRow int) []byte {
+	bytesPerRow := 0
+	for _, channel := range channels {
+		bytesPerRow += exrPixelSize(channel.PixelType) * e.width
+	}
+
+	data := make([]byte, (endRow-startRow)*bytesPerRow)
+	position := 0
+
+	for y := startRow; y < endRow; y++ {
+		for _, channel := range channels {
+			for _, value := range channel.Values[y*e.width : (y+1)*e.width] {
+				switch channel.PixelType {
+				case ExrPixelHalf:
+					binary.LittleEndian.PutUint16(data[position:], float32ToHalf(float32(value)))
+				case ExrPixelFloat:
+					binary.LittleEndian.PutUint32(data[position:], math.Float32bits(float32(value)))
+				default:
+					binary.LittleEndian.PutUint32(data[position:], uint32(math.Min(math.Max(math.Round(value), 0.0), math.MaxUint32)))
+				}
+
+				position += exrPixelSize(channel.PixelType)
+			}
+		}
+	}
+
+	return data
+}
+
+// Compress pixel data the way OpenEXR does for ZIP compression. The bytes are split into two halves and every
+// byte is replaced by its difference with the previous byte before compressing, which makes floating point data
+// compress better. Data that does not get smaller is stored uncompressed.
+func zipCompress(data []byte) ([]byte, error) {
+	reordered := make([]byte, len(data))
+	half := (len(data) + 1) / 2
+
+	for i := range data {
+		if i%2 == 0 {
+			reordered[i/2] = data[i]
+		} else {
+			reordered[half+i/2] = data[i]
+		}
+	}
+
+	for i := len(reordered) - 1; i > 0; i-- {
+		reordered[i] = byte(int(reordered[i]) - int(reordered[i-1]) + 128 + 256)
+	}
+
+	compressed := bytes.Buffer{}
+	compressor := zlib.NewWriter(&compressed)
+
+	if _, error := compressor.Write(reordered); error != nil {
+		return nil, error
+	}
+
+	if error := compressor.Close(); error != nil {
+		return nil, error
+	}
+
+	if compressed.Len() >= len(data) {
+		return data, nil
+	}
+
+	return compressed.Bytes(), nil
+}
+
+// Undo the ZIP compression of OpenEXR
+func zipDecompress(data []byte, size int) ([]byte, error) {
+	decompressor, error := zlib.NewReader(bytes.NewReader(data))
+	if error != nil {
+		return nil, error
+	}
+
+	reordered := make([]byte, size)
+	if _, error := io.ReadFull(decompressor, reordered); error != nil {
+		return nil, error
+	}
+
+	for i := 1; i < len(reordered); i++ {
+		reordered[i] = byte(int(reordered[i-1]) + int(reordered[i]) - 128)
+	}
+
+	result := make([]byte, size)
+	half := (size + 1) / 2
+
+	for i := range result {
+		if i%2 == 0 {
+			result[i] = reordered[i/2]
+		} else {
+			result[i] = reordered[half+i/2]
+		}
+	}
+
+	return result, nil
+}
+
+// Read an OpenEXR file from disk
+func ReadExrFile(fileName string) (ExrImage, error) {
+	file, error := os.Open(fileName)
+
+	// Unable to open the file
+	if error != nil {
+		return ExrImage{}, errors.New(fmt.Sprintf("Unable to read %s from disk: %s", fileName, error.Error()))
+	}
+
+	defer file.Close()
+	return ReadExr(file)
+}
+
+// Cursor over the bytes of an OpenEXR file that reports reads past the end of the file as errors
+type exrReader struct {
+	data     []byte
+	position int
+}
+
+// Read the specified number of bytes
+func (r *exrReader) bytes(count int) ([]byte, error) {
+	if count < 0 || r.position < 0 || r.position+count > len(r.data) {
+		return nil, errors.New("Unexpected end of the file")
+	}
+
+	r.position += count
+	return r.data[r.position-count : r.position], nil
+}
+
+// Read a null-terminated string
+func (r *exrReader) string() (string, error) {
+	if r.position < 0 || r.position >= len(r.data) {
+		return "", errors.New("Unexpected end of the file")
+	}
+
+	end := bytes.IndexByte(r.data[r.position:], 0)
+	if end < 0 {
+		return "", errors.New("Unterminated string")
+	}
+
+	value := string(r.data[r.position : r.position+end])
+	r.position += end + 1
+	return value, nil
+}
+
+// Read a little endian 32-bit integer
+func (r *exrReader) int32() (int32, error) {
+	value, error := r.bytes(4)
+	if error != nil {
+		return 0, error
+	}
+
+	return int32(binary.LittleEndian.Uint32(value)), nil
+}
+
+// Read a single-part scanline OpenEXR image that is either uncompressed or ZIP compressed
+func ReadExr(reader io.Reader) (ExrImage, error) {
+	data, error := io.ReadAll(reader)
+	if error != nil {
+		return ExrImage{}, error
+	}
+
+	input := &exrReader{data: data}
+	magic, error := input.int32()
+	if error != nil || magic != exrMagicNumber {
+		return ExrImage{}, errors.New("Not an OpenEXR file")
+	}
+
+	version, error := input.int32()
+	if error != nil {
+		return ExrImage{}, error
+	}
+
+	if version&0xff != exrVersion || version&exrUnsupportedFlags != 0 {
+		return ExrImage{}, errors.New("Only single-part scanline OpenEXR files are supported")
+	}
+
+	image, compression, minX, minY, error := readExrHeader(input)
+	if error != nil {
+		return ExrImage{}, error
+	}
+
+	if minX != 0 {
+		return ExrImage{}, errors.New("Data windows that do not start at the left edge are not supported")
+	}
+
+	linesPerChunk := exrLinesPerChunk(compression)
+	chunkCount := (image.height + linesPerChunk - 1) / linesPerChunk
+
+	bytesPerRow := 0
+	for i := range image.channels {
+		bytesPerRow += exrPixelSize(image.channels[i].PixelType) * image.width
+		image.channels[i].Values = make([]float64, image.width*image.height)
+	}
+
+	offsetTable, error := input.bytes(8 * chunkCount)
+	if error != nil {
+		return ExrImage{}, error
+	}
+
+	// Chunks are stored after the offset table, offsets pointing anywhere else come from a corrupt file
+	chunksStart := input.position
+
+	for i := 0; i < chunkCount; i++ {
+		offset := binary.LittleEndian.Uint64(offsetTable[i*8:])
+		if offset < uint64(chunksStart) || offset >= uint64(len(data)) {
+			return ExrImage{}, errors.New(fmt.Sprintf("Chunk %d has an invalid offset of %d", i, offset))
+		}
+
+		input.position = int(offset)
+		chunkY, error := input.int32()
+		if error != nil {
+			return ExrImage{}, error
+		}
+
+		size, error := input.int32()
+		if error != nil {
+			return ExrImage{}, error
+		}
+
+		chunk, error := input.bytes(int(size))
+		if error != nil {
+			return ExrImage{}, error
+		}
+
+		startRow := int(chunkY) - minY
+		rowCount := int(math.Min(float64(linesPerChunk), float64(image.height-startRow)))
+		if startRow < 0 || rowCount <= 0 {
+			return ExrImage{}, errors.New(fmt.Sprintf("Chunk at row %d lies outside of the image", chunkY))
+		}
+
+		// Chunks that did not get smaller when compressing them are stored uncompressed
+		expectedSize := rowCount * bytesPerRow
+		if compression != ExrCompressionNone && len(chunk) < expectedSize {
+			if chunk, error = zipDecompress(chunk, expectedSize); error != nil {
+				return ExrImage{}, error
+			}
+		}
+
+		if len(chunk) != expectedSize {
+			return ExrImage{}, errors.New(fmt.Sprintf("Chunk at row %d has an unexpected size", chunkY))
+		}
+
+		image.decodeRows(chunk, startRow, rowCount)
+	}
+
+	return image, nil
+}
+
+// Read the header attributes, only the attributes needed to read the pixel data are interpreted
+func readExrHeader(input *exrReader) (ExrImage, ExrCompression, int, int, error) {
+	image := ExrImage{}
+	compression := ExrCompressionNone
+	window := []int32{}
+
+	for {
+		name, error := input.string()
+		if error != nil {
+			return image, compression, 0, 0, error
+		}
+
+		// The header ends with an empty attribute name
+		if name == "" {
+			break
+		}
+
+		attributeType, error := input.string()
+		if error != nil {
+			return image, compression, 0, 0, error
+		}
+
+		size, error := input.int32()
+		if error != nil {
+			return image, compression, 0, 0, error
+		}
+
+		value, error := input.bytes(int(size))
+		if error != nil {
+			return image, compression, 0, 0, error
+		}
+
+		switch {
+		case name == "channels" && attributeType == "chlist":
+			if image.channels, error = readExrChannelList(value); error != nil {
+				return image, compression, 0, 0, error
+			}
+		case name == "compression" && len(value) == 1:
+			compression = ExrCompression(value[0])
+		case name == "dataWindow" && len(value) == 16:
+			for i := 0; i < 4; i++ {
+				window = append(window, int32(binary.LittleEndian.Uint32(value[i*4:])))
+			}
+		}
+	}
+
+	if compression != ExrCompressionNone && compression != ExrCompressionZips && compression != ExrCompressionZip {
+		return image, compression, 0, 0, errors.New(fmt.Sprintf("Unsupported compression %d", compression))
+	}
+
+	if len(window) != 4 || window[2] < window[0] || window[3] < window[1] {
+		return image, compression, 0, 0, errors.New("Missing or invalid data window")
+	}
+
+	image.width = int(window[2]-window[0]) + 1
+	image.height = int(window[3]-window[1]) + 1
+
+	return image, compression, int(window[0]), int(window[1]), nil
+}
+
+// Read the names and pixel types of all channels
+func readExrChannelList(value []byte) ([]ExrChannel, error) {
+	input := &exrReader{data: value}
+	channels := []ExrChannel{}
+
+	for {
+		name, error := input.string()
+		if error != nil {
+			return nil, error
+		}
+
+		if name == "" {
+			return channels, nil
+		}
+
+		pixelType, error := input.int32()
+		if error != nil {
+			return nil, error
+		}
+
+		// Linear flag and reserved bytes
+		if _, error := input.bytes(4); error != nil {
+			return nil, error
+		}
+
+		sampling, error := input.bytes(8)
+		if error != nil {
+			return nil, error
+		}
+
+		if pixelType < int32(ExrPixelUint) || pixelType > int32(ExrPixelFloat) {
+			return nil, errors.New(fmt.Sprintf("Channel %s has an unknown pixel type", name))
+		}
+
+		if binary.LittleEndian.Uint32(sampling) != 1 || binary.LittleEndian.Uint32(sampling[4:]) != 1 {
+			return nil, errors.New(fmt.Sprintf("Channel %s is subsampled, which is not supported", name))
+		}
+
+		channels = append(channels, ExrChannel{Name: name, PixelType: ExrPixelType(pixelType)})
+	}
+}
+
+// Read the values of a range of rows, within each row the values are grouped per channel
+func (e *ExrImage) decodeRows(data []byte, startRow int, rowCount int) {
+	position := 0
+
+	for y := startRow; y < startRow+rowCount; y++ {
+		for _, channel := range e.channels {
+			for x := 0; x < e.width; x++ {
+				switch channel.PixelType {
+				case ExrPixelHalf:
+					channel.Values[y*e.width+x] = float64(halfToFloat32(binary.LittleEndian.Uint16(data[position:])))
+				case ExrPixelFloat:
+					channel.Values[y*e.width+x] = float64(math.Float32frombits(binary.LittleEndian.Uint32(data[position:])))
+				default:
+					channel.Values[y*e.width+x] = float64(binary.LittleEndian.Uint32(data[position:]))
+				}
+
+				position += exrPixelSize(channel.PixelType)
+			}
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package utility
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+func TestFloat32ToHalf(t *testing.T) {
+	cases := []struct {
+		value    float32
+		expected uint16
+	}{
+		{0.0, 0x0000},
+		{1.0, 0x3c00},
+		{-2.0, 0xc000},
+		{65504.0, 0x7bff},
+		{float32(math.Ldexp(1.0, -24)), 0x0001},
+		{float32(math.Ldexp(1.0, -14)), 0x0400},
+		{100000.0, 0x7c00},
+		{float32(math.Inf(-1)), 0xfc00},
+
+		// Halfway between 1.0 and the next half, rounds to the even value
+		{1.0 + float32(math.Ldexp(1.0, -11)), 0x3c00},
+	}
+
+	for _, c := range cases {
+		if half := float32ToHalf(c.value); half != c.expected {
+			t.Fatalf("Half float failure: expected %g to become %#04x but got %#04x", c.value, c.expected, half)
+		}
+	}
+
+	if half := float32ToHalf(float32(math.NaN())); half&0x7c00 != 0x7c00 || half&0x03ff == 0 {
+		t.Fatalf("Half float failure: expected NaN to stay NaN but got %#04x", half)
+	}
+}
+
+func TestHalfToFloat32(t *testing.T) {
+	for half := 0; half < 0x7c00; half++ {
+		if result := float32ToHalf(halfToFloat32(uint16(half))); result != uint16(half) {
+			t.Fatalf("Half float failure: expected %#04x to round trip but got %#04x", half, result)
+		}
+	}
+
+	if value := halfToFloat32(0x7c00); !math.IsInf(float64(value), 1) {
+		t.Fatalf("Half float failure: expected positive infinity but got %g", value)
+	}
+}
+
+func testExrImage(t *testing.T, pixelType ExrPixelType) ExrImage {
+	framebuffer := NewFramebuffer(5, 37)
+	for y := 0; y < framebuffer.GetHeight(); y++ {
+		for x := 0; x < framebuffer.GetWidth(); x++ {
+			framebuffer.SetPixel(x, y, Vec3{X: float64(x) * 0.25, Y: float64(y) * 4.0, Z: -0.5}, 1.0)
+		}
+	}
+
+	image := NewExrImage(5, 37)
+	if error := image.AddFramebuffer("", &framebuffer, pixelType); error != nil {
+		t.Fatalf("OpenEXR failure: %s", error.Error())
+	}
+
+	depth := make([]float64, 5*37)
+	for i := range depth {
+		depth[i] = float64(i)
+	}
+
+	if error := image.AddChannel("depth.Z", ExrPixelUint, depth); error != nil {
+		t.Fatalf("OpenEXR failure: %s", error.Error())
+	}
+
+	return image
+}
+
+func TestExrRoundTrip(t *testing.T) {
+	for _, pixelType := range []ExrPixelType{ExrPixelHalf, ExrPixelFloat} {
+		for _, compression := range []ExrCompression{ExrCompressionNone, ExrCompressionZips, ExrCompressionZip} {
+			image := testExrImage(t, pixelType)
+			buffer := bytes.Buffer{}
+
+			if error := image.Write(&buffer, compression); error != nil {
+				t.Fatalf("OpenEXR failure: %s", error.Error())
+			}
+
+			result, error := ReadExr(&buffer)
+			if error != nil {
+				t.Fatalf("OpenEXR failure: %s", error.Error())
+			}
+
+			if result.GetWidth() != 5 || result.GetHeight() != 37 {
+				t.Fatalf("OpenEXR failure: expected a 5x37 image but got %dx%d", result.GetWidth(), result.GetHeight())
+			}
+
+			for _, name := range image.GetChannelNames() {
+				expected, _ := image.GetChannel(name)
+				channel, exists := result.GetChannel(name)
+
+				if !exists || channel.PixelType != expected.PixelType {
+					t.Fatalf("OpenEXR failure: channel %s did not survive compression %d", name, compression)
+				}
+
+				for i, value := range expected.Values {
+					if channel.Values[i] != value {
+						t.Fatalf("OpenEXR failure: expected %g in channel %s but got %g", value, name, channel.Values[i])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestExrLayerNames(t *testing.T) {
+	framebuffer := NewFramebuffer(2, 2)
+	image := NewExrImage(2, 2)
+
+	if error := image.AddFramebuffer("normal", &framebuffer, ExrPixelHalf, "X", "Y", "Z"); error != nil {
+		t.Fatalf("OpenEXR failure: %s", error.Error())
+	}
+
+	if _, exists := image.GetChannel("normal.Y"); !exists {
+		t.Fatalf("OpenEXR failure: expected the layer name to prefix the channel names but got %v", image.GetChannelNames())
+	}
+
+	if error := image.AddFramebuffer("normal", &framebuffer, ExrPixelHalf, "X"); error == nil {
+		t.Fatalf("OpenEXR failure: expected an error when adding a duplicate channel")
+	}
+
+	if error := image.AddChannel("mask", ExrPixelHalf, []float64{1.0}); error == nil {
+		t.Fatalf("OpenEXR failure: expected an error when a channel does not cover every pixel")
+	}
+}
+
+func TestReadExrInvalid(t *testing.T) {
+	if _, error := ReadExr(bytes.NewReader([]byte("#?RADIANCE\n"))); error == nil {
+		t.Fatalf("OpenEXR failure: expected an error when reading a file that is not an OpenEXR file")
+	}
+
+	image := testExrImage(t, ExrPixelHalf)
+	buffer := bytes.Buffer{}
+	image.Write(&buffer, ExrCompressionZip)
+
+	if _, error := ReadExr(bytes.NewReader(buffer.Bytes()[:buffer.Len()-10])); error == nil {
+		t.Fatalf("OpenEXR failure: expected an error when reading a truncated file")
+	}
+}
+
+func TestReadExrTruncated(t *testing.T) {
+	image := testExrImage(t, ExrPixelFloat)
+	buffer := bytes.Buffer{}
+	image.Write(&buffer, ExrCompressionNone)
+	data := buffer.Bytes()
+
+	// Cutting the file off anywhere, including in the middle of the header and the chunk headers, is an error
+	for length := 0; length < len(data); length++ {
+		if _, error := ReadExr(bytes.NewReader(data[:length])); error == nil {
+			t.Fatalf("OpenEXR failure: expected an error when reading the first %d of %d bytes", length, len(data))
+		}
+	}
+}
+
+func TestReadExrCorruptOffsets(t *testing.T) {
+	image := testExrImage(t, ExrPixelHalf)
+	buffer := bytes.Buffer{}
+	image.Write(&buffer, ExrCompressionNone)
+	data := buffer.Bytes()
+
+	// Without compression every scanline is a chunk, the first offset points right past the offset table
+	table := -1
+	for i := 0; i+8 <= len(data); i++ {
+		if binary.LittleEndian.Uint64(data[i:]) == uint64(i+8*37) {
+			table = i
+			break
+		}
+	}
+
+	if table < 0 {
+		t.Fatalf("OpenEXR failure: unable to find the offset table")
+	}
+
+	for _, offset := range []uint64{math.MaxUint64 - 3, 8, uint64(table), uint64(len(data))} {
+		corrupt := make([]byte, len(data))
+		copy(corrupt, data)
+		binary.LittleEndian.PutUint64(corrupt[table:], offset)
+
+		if _, error := ReadExr(bytes.NewReader(corrupt)); error == nil {
+			t.Fatalf("OpenEXR failure: expected an error when a chunk offset of %d is corrupt", offset)
+		}
+	}
+}
+
+func TestExrLongChannelNames(t *testing.T) {
+	framebuffer := NewFramebuffer(2, 2)
+	image := NewExrImage(2, 2)
+	layer := strings.Repeat("layer", 8)
+
+	if error := image.AddFramebuffer(layer, &framebuffer, ExrPixelHalf, "Y"); error != nil {
+		t.Fatalf("OpenEXR failure: %s", error.Error())
+	}
+
+	buffer := bytes.Buffer{}
+	image.Write(&buffer, ExrCompressionZip)
+
+	if version := binary.LittleEndian.Uint32(buffer.Bytes()[4:]); version&0x400 == 0 {
+		t.Fatalf("OpenEXR failure: expected the long names flag to be set for names longer than 31 bytes")
+	}
+
+	result, error := ReadExr(&buffer)
+	if error != nil {
+		t.Fatalf("OpenEXR failure: %s", error.Error())
+	}
+
+	if _, exists := result.GetChannel(layer + ".Y"); !exists {
+		t.Fatalf("OpenEXR failure: expected the long channel name to survive but got %v", result.GetChannelNames())
+	}
+
+	if error := image.AddChannel(strings.Repeat("a", 256), ExrPixelHalf, make([]float64, 4)); error == nil {
+		t.Fatalf("OpenEXR failure: expected an error when a channel name is longer than 255 bytes")
+	}
+}
@@ -0,0 +1,113 @@
+package utility
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Writes framebuffers as Portable FloatMap images, the linear colors are stored as 32-bit floats and alpha is
+// discarded
+type PfmWriter struct{}
+
+// Create a new Portable FloatMap image writer
+func NewPfmWriter() *PfmWriter {
+	return &PfmWriter{}
+}
+
+// Write the framebuffer as a little endian color Portable FloatMap, rows are stored from bottom to top
+//
+// Reference: https://www.pauldebevec.com/Research/HDR/PFM/
+func (p *PfmWriter) Write(writer io.Writer, framebuffer *Framebuffer) error {
+	output := bufio.NewWriter(writer)
+	width, height := framebuffer.GetWidth(), framebuffer.GetHeight()
+
+	// A negative scale marks the data as little endian
+	fmt.Fprintf(output, "PF\n%d %d\n-1.0\n", width, height)
+
+	row := make([]byte, width*12)
+	for y := height - 1; y >= 0; y-- {
+		for x := 0; x < width; x++ {
+			color := framebuffer.GetPixel(x, y)
+			binary.LittleEndian.PutUint32(row[x*12:], math.Float32bits(float32(color.X)))
+			binary.LittleEndian.PutUint32(row[x*12+4:], math.Float32bits(float32(color.Y)))
+			binary.LittleEndian.PutUint32(row[x*12+8:], math.Float32bits(float32(color.Z)))
+		}
+
+		output.Write(row)
+	}
+
+	return output.Flush()
+}
+
+// Read a Portable FloatMap file from disk
+func ReadPfmFile(fileName string) (Framebuffer, error) {
+	file, error := os.Open(fileName)
+
+	// Unable to open the file
+	if error != nil {
+		return Framebuffer{}, errors.New(fmt.Sprintf("Unable to read %s from disk: %s", fileName, error.Error()))
+	}
+
+	defer file.Close()
+	return ReadPfm(file)
+}
+
+// Read a color (PF) or grayscale (Pf) Portable FloatMap image in either byte order
+func ReadPfm(reader io.Reader) (Framebuffer, error) {
+	input := bufio.NewReader(reader)
+
+	format, width, height, scale := "", 0, 0, 0.0
+	if _, error := fmt.Fscan(input, &format, &width, &height, &scale); error != nil || (format != "PF" && format != "Pf") {
+		return Framebuffer{}, errors.New("Not a Portable FloatMap file")
+	}
+
+	if width <= 0 || height <= 0 || scale == 0.0 {
+		return Framebuffer{}, errors.New(fmt.Sprintf("Unsupported resolution %dx%d or scale %g", width, height, scale))
+	}
+
+	// A single whitespace character separates the header from the pixel data
+	if _, error := input.ReadByte(); error != nil {
+		return Framebuffer{}, errors.New("Unexpected end of the header")
+	}
+
+	var byteOrder binary.ByteOrder = binary.BigEndian
+	if scale < 0.0 {
+		byteOrder = binary.LittleEndian
+	}
+
+	channels := 3
+	if format == "Pf" {
+		channels = 1
+	}
+
+	image := NewFramebuffer(width, height)
+	row := make([]byte, width*channels*4)
+
+	for y := height - 1; y >= 0; y-- {
+		if _, error := io.ReadFull(input, row); error != nil {
+			return Framebuffer{}, errors.New(fmt.Sprintf("Unable to read row %d: %s", y, error.Error()))
+		}
+
+		for x := 0; x < width; x++ {
+			values := [3]float64{}
+			for c := 0; c < channels; c++ {
+				values[c] = float64(math.Float32frombits(byteOrder.Uint32(row[(x*channels+c)*4:])))
+			}
+
+			if channels == 1 {
+				values[1], values[2] = values[0], values[0]
+			}
+
+			image.SetPixel(x, y, Vec3{X: values[0], Y: values[1], Z: values[2]}, 1.0)
+		}
+	}
+
+	return image, nil
+}
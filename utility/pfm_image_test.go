@@ -0,0 +1,63 @@
+package utility
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+func TestPfmRoundTrip(t *testing.T) {
+	framebuffer := NewFramebuffer(3, 2)
+	framebuffer.SetPixel(0, 0, Vec3{X: 1.0, Y: 2.0, Z: 3.0}, 1.0)
+	framebuffer.SetPixel(2, 1, Vec3{X: -0.5, Y: 1000.0, Z: 0.125}, 1.0)
+
+	buffer := bytes.Buffer{}
+	if error := NewPfmWriter().Write(&buffer, &framebuffer); error != nil {
+		t.Fatalf("PFM failure: %s", error.Error())
+	}
+
+	if !bytes.HasPrefix(buffer.Bytes(), []byte("PF\n3 2\n-1.0\n")) {
+		t.Fatalf("PFM failure: unexpected header %q", buffer.Bytes()[:12])
+	}
+
+	image, error := ReadPfm(&buffer)
+	if error != nil {
+		t.Fatalf("PFM failure: %s", error.Error())
+	}
+
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			if image.GetPixel(x, y) != framebuffer.GetPixel(x, y) {
+				t.Fatalf("PFM failure: expected %v at %d, %d but got %v", framebuffer.GetPixel(x, y), x, y, image.GetPixel(x, y))
+			}
+		}
+	}
+}
+
+func TestReadPfmGrayscaleBigEndian(t *testing.T) {
+	data := append([]byte("Pf\n2 1\n1.0\n"), make([]byte, 8)...)
+	binary.BigEndian.PutUint32(data[len(data)-8:], math.Float32bits(0.25))
+	binary.BigEndian.PutUint32(data[len(data)-4:], math.Float32bits(4.0))
+
+	image, error := ReadPfm(bytes.NewReader(data))
+	if error != nil {
+		t.Fatalf("PFM failure: %s", error.Error())
+	}
+
+	if pixel := image.GetPixel(1, 0); pixel != (Vec3{X: 4.0, Y: 4.0, Z: 4.0}) {
+		t.Fatalf("PFM failure: expected {4 4 4} but got %v", pixel)
+	}
+}
+
+func TestReadPfmInvalid(t *testing.T) {
+	if _, error := ReadPfm(bytes.NewReader([]byte("P6\n1 1\n255\n"))); error == nil {
+		t.Fatalf("PFM failure: expected an error when reading a file that is not a Portable FloatMap")
+	}
+
+	if _, error := ReadPfm(bytes.NewReader([]byte("PF\n2 2\n-1.0\n\x00\x00"))); error == nil {
+		t.Fatalf("PFM failure: expected an error when reading a truncated file")
+	}
+}
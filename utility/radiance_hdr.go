@@ -0,0 +1,270 @@
+package utility
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Read a Radiance .hdr file from disk
+func ReadRadianceHdrFile(fileName string) (Framebuffer, error) {
+	file, error := os.Open(fileName)
+
+	// Unable to open the file
+	if error != nil {
+		return Framebuffer{}, errors.New(fmt.Sprintf("Unable to read %s from disk: %s", fileName, error.Error()))
+	}
+
+	defer file.Close()
+	return ReadRadianceHdr(file)
+}
+
+// Read an image in the Radiance .hdr format, which stores each pixel as an 8-bit mantissa per color channel
+// with a shared exponent (RGBE). Both flat and run-length encoded scanlines are supported, only the standard
+// orientation of rows from top to bottom and columns from left to right is supported.
+//
+// Reference: https://www.graphics.cornell.edu/~bjw/rgbe.html
+func ReadRadianceHdr(reader io.Reader) (Framebuffer, error) {
+	input := bufio.NewReader(reader)
+	width, height, error := readRadianceHdrHeader(input)
+
+	if error != nil {
+		return Framebuffer{}, error
+	}
+
+	image := NewFramebuffer(width, height)
+	scanline := make([]byte, width*4)
+
+	for y := 0; y < height; y++ {
+		if error := readRadianceHdrScanline(input, scanline); error != nil {
+			return Framebuffer{}, errors.New(fmt.Sprintf("Unable to read scanline %d: %s", y, error.Error()))
+		}
+
+		for x := 0; x < width; x++ {
+			image.SetPixel(x, y, rgbeToVec3(scanline[x*4:x*4+4]), 1.0)
+		}
+	}
+
+	return image, nil
+}
+
+// Read the header of a Radiance .hdr file and return the resolution of the image
+func readRadianceHdrHeader(input *bufio.Reader) (int, int, error) {
+	line, error := input.ReadString('\n')
+	if error != nil || !strings.HasPrefix(line, "#?") {
+		return 0, 0, errors.New("Not a Radiance .hdr file")
+	}
+
+	// Header variables end at an empty line
+	for {
+		line, error = input.ReadString('\n')
+		if error != nil {
+			return 0, 0, errors.New("Unexpected end of the header")
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+
+		if strings.HasPrefix(line, "FORMAT=") && line != "FORMAT=32-bit_rle_rgbe" {
+			return 0, 0, errors.New(fmt.Sprintf("Unsupported pixel format %s", line))
+		}
+	}
+
+	line, error = input.ReadString('\n')
+	if error != nil {
+		return 0, 0, errors.New("Missing resolution")
+	}
+
+	width, height := 0, 0
+	if _, error := fmt.Sscanf(line, "-Y %d +X %d", &height, &width); error != nil || width <= 0 || height <= 0 {
+		return 0, 0, errors.New(fmt.Sprintf("Unsupported resolution %s", strings.TrimSpace(line)))
+	}
+
+	return width, height, nil
+}
+
+// Read a single scanline of RGBE pixels, the scanline is either flat or run-length encoded per channel
+func readRadianceHdrScanline(input *bufio.Reader, scanline []byte) error {
+	width := len(scanline) / 4
+
+	if _, error := io.ReadFull(input, scanline[:4]); error != nil {
+		return error
+	}
+
+	// Run-length encoded scanlines start with two bytes of 2 followed by the width, a flat pixel never looks like that
+	isRunLengthEncoded := width >= 8 && width < 32768 && scanline[0] == 2 && scanline[1] == 2 && scanline[2]&0x80 == 0
+	if !isRunLengthEncoded {
+		_, error := io.ReadFull(input, scanline[4:])
+		return error
+	}
+
+	if int(scanline[2])<<8|int(scanline[3]) != width {
+		return errors.New("Scanline width does not match the image width")
+	}
+
+	// Each channel is stored separately as a sequence of runs and literal bytes
+	for channel := 0; channel < 4; channel++ {
+		for x := 0; x < width; {
+			count, error := input.ReadByte()
+			if error != nil {
+				return error
+			}
+
+			if count > 128 {
+				value, error := input.ReadByte()
+				if error != nil {
+					return error
+				}
+
+				count -= 128
+				if x+int(count) > width {
+					return errors.New("Run exceeds the scanline")
+				}
+
+				for i := 0; i < int(count); i++ {
+					scanline[(x+i)*4+channel] = value
+				}
+			} else {
+				if count == 0 || x+int(count) > width {
+					return errors.New("Invalid literal length")
+				}
+
+				for i := 0; i < int(count); i++ {
+					value, error := input.ReadByte()
+					if error != nil {
+						return error
+					}
+
+					scanline[(x+i)*4+channel] = value
+				}
+			}
+
+			x += int(count)
+		}
+	}
+
+	return nil
+}
+
+// Convert an RGBE pixel into a linear color
+func rgbeToVec3(rgbe []byte) Vec3 {
+	if rgbe[3] == 0 {
+		return Vec3{}
+	}
+
+	scale := math.Ldexp(1.0, int(rgbe[3])-(128+8))
+	return Vec3{X: float64(rgbe[0]) * scale, Y: float64(rgbe[1]) * scale, Z: float64(rgbe[2]) * scale}
+}
+
+// Writes framebuffers as Radiance .hdr images, the linear colors are stored as is and alpha is discarded
+type RadianceHdrWriter struct{}
+
+// Create a new Radiance .hdr image writer
+func NewRadianceHdrWriter() *RadianceHdrWriter {
+	return &RadianceHdrWriter{}
+}
+
+// Write the framebuffer as a Radiance .hdr image, scanlines are run-length encoded whenever the width allows it
+func (r *RadianceHdrWriter) Write(writer io.Writer, framebuffer *Framebuffer) error {
+	output := bufio.NewWriter(writer)
+	width, height := framebuffer.GetWidth(), framebuffer.GetHeight()
+
+	fmt.Fprintf(output, "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y %d +X %d\n", height, width)
+
+	scanline := make([]byte, width*4)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			vec3ToRgbe(framebuffer.GetPixel(x, y), scanline[x*4:x*4+4])
+		}
+
+		writeRadianceHdrScanline(output, scanline)
+	}
+
+	return output.Flush()
+}
+
+// Convert a linear color into an RGBE pixel, negative values are clamped to zero
+func vec3ToRgbe(color Vec3, rgbe []byte) {
+	color = ComponentMax(color, Vec3{})
+	largest := math.Max(color.X, math.Max(color.Y, color.Z))
+
+	if largest < 1e-32 {
+		copy(rgbe, []byte{0, 0, 0, 0})
+		return
+	}
+
+	mantissa, exponent := math.Frexp(largest)
+	scale := mantissa * 256.0 / largest
+
+	rgbe[0] = byte(color.X * scale)
+	rgbe[1] = byte(color.Y * scale)
+	rgbe[2] = byte(color.Z * scale)
+	rgbe[3] = byte(ClampBetween(float64(exponent+128), 0.0, 255.0))
+}
+
+// Write a single scanline of RGBE pixels, each channel is run-length encoded separately
+func writeRadianceHdrScanline(output *bufio.Writer, scanline []byte) {
+	width := len(scanline) / 4
+
+	// Scanlines that are too narrow or too wide can only be stored flat
+	if width < 8 || width >= 32768 {
+		output.Write(scanline)
+		return
+	}
+
+	output.Write([]byte{2, 2, byte(width >> 8), byte(width & 0xff)})
+
+	channel := make([]byte, width)
+	for c := 0; c < 4; c++ {
+		for x := 0; x < width; x++ {
+			channel[x] = scanline[x*4+c]
+		}
+
+		writeRadianceHdrRuns(output, channel)
+	}
+}
+
+// Encode bytes as a sequence of runs and literals, only runs of at least four equal bytes are worth storing as a run
+//
+// Reference: https://www.graphics.cornell.edu/~bjw/rgbe.html
+func writeRadianceHdrRuns(output *bufio.Writer, data []byte) {
+	const minimumRunLength = 4
+
+	for x := 0; x < len(data); {
+		// Find the start of the next run that is long enough
+		runStart, runLength := x, 0
+		for runStart < len(data) {
+			runLength = 1
+			for runStart+runLength < len(data) && runLength < 127 && data[runStart+runLength] == data[runStart] {
+				runLength++
+			}
+
+			if runLength >= minimumRunLength {
+				break
+			}
+
+			runStart += runLength
+		}
+
+		// Everything before the run is stored as literals
+		for x < runStart {
+			count := int(math.Min(float64(runStart-x), 128.0))
+			output.WriteByte(byte(count))
+			output.Write(data[x : x+count])
+			x += count
+		}
+
+		if runStart < len(data) {
+			output.Write([]byte{byte(128 + runLength), data[runStart]})
+			x = runStart + runLength
+		}
+	}
+}
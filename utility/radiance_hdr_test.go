@@ -0,0 +1,99 @@
+package utility
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+const radianceHdrHeader = "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n"
+
+func TestReadRadianceHdrFlat(t *testing.T) {
+	data := append([]byte(radianceHdrHeader+"-Y 1 +X 2\n"), 128, 64, 32, 129, 0, 0, 0, 0)
+	image, error := ReadRadianceHdr(bytes.NewReader(data))
+
+	if error != nil {
+		t.Fatalf("Radiance HDR failure: %s", error.Error())
+	}
+
+	if image.GetWidth() != 2 || image.GetHeight() != 1 {
+		t.Fatalf("Radiance HDR failure: expected a 2x1 image but got %dx%d", image.GetWidth(), image.GetHeight())
+	}
+
+	if pixel := image.GetPixel(0, 0); pixel != (Vec3{X: 1.0, Y: 0.5, Z: 0.25}) {
+		t.Fatalf("Radiance HDR failure: expected {1 0.5 0.25} but got %v", pixel)
+	}
+
+	if pixel := image.GetPixel(1, 0); pixel != (Vec3{}) {
+		t.Fatalf("Radiance HDR failure: expected a zero exponent to result in black but got %v", pixel)
+	}
+}
+
+func TestReadRadianceHdrRunLengthEncoded(t *testing.T) {
+	data := append([]byte(radianceHdrHeader+"-Y 1 +X 8\n"), 2, 2, 0, 8)
+
+	// Red is a single run, green is a run of 4 followed by 4 literal bytes, blue and the exponent are single runs
+	data = append(data, 128+8, 128)
+	data = append(data, 128+4, 64, 4, 0, 64, 128, 255)
+	data = append(data, 128+8, 0)
+	data = append(data, 128+8, 129)
+
+	image, error := ReadRadianceHdr(bytes.NewReader(data))
+	if error != nil {
+		t.Fatalf("Radiance HDR failure: %s", error.Error())
+	}
+
+	expectedGreen := []float64{0.5, 0.5, 0.5, 0.5, 0.0, 0.5, 1.0, 255.0 / 128.0}
+	for x, green := range expectedGreen {
+		if pixel := image.GetPixel(x, 0); pixel != (Vec3{X: 1.0, Y: green, Z: 0.0}) {
+			t.Fatalf("Radiance HDR failure: expected {1 %f 0} at %d but got %v", green, x, pixel)
+		}
+	}
+}
+
+func TestReadRadianceHdrInvalid(t *testing.T) {
+	if _, error := ReadRadianceHdr(bytes.NewReader([]byte("P6\n1 1\n255\n"))); error == nil {
+		t.Fatalf("Radiance HDR failure: expected an error when reading a file that is not a Radiance .hdr file")
+	}
+
+	if _, error := ReadRadianceHdr(bytes.NewReader([]byte(radianceHdrHeader + "-Y 2 +X 2\n"))); error == nil {
+		t.Fatalf("Radiance HDR failure: expected an error when the pixel data is missing")
+	}
+}
+
+func TestRadianceHdrRoundTrip(t *testing.T) {
+	// Narrow images are stored flat, wider images are run-length encoded
+	for _, width := range []int{3, 300} {
+		framebuffer := NewFramebuffer(width, 2)
+		for x := 0; x < width; x++ {
+			framebuffer.SetPixel(x, 0, Vec3{X: 1.0, Y: 0.5, Z: float64(x%4) * 0.25}, 1.0)
+			framebuffer.SetPixel(x, 1, Vec3{X: float64(x) * 64.0, Y: 0.0, Z: -1.0}, 1.0)
+		}
+
+		buffer := bytes.Buffer{}
+		if error := NewRadianceHdrWriter().Write(&buffer, &framebuffer); error != nil {
+			t.Fatalf("Radiance HDR failure: %s", error.Error())
+		}
+
+		image, error := ReadRadianceHdr(&buffer)
+		if error != nil {
+			t.Fatalf("Radiance HDR failure: %s", error.Error())
+		}
+
+		for y := 0; y < 2; y++ {
+			for x := 0; x < width; x++ {
+				expected := ComponentMax(framebuffer.GetPixel(x, y), Vec3{})
+				pixel := image.GetPixel(x, y)
+
+				// RGBE stores 8 bits of precision relative to the largest channel
+				tolerance := math.Max(expected.X, math.Max(expected.Y, expected.Z)) / 128.0
+				difference := Abs(Sub(pixel, expected))
+				if difference.X > tolerance || difference.Y > tolerance || difference.Z > tolerance {
+					t.Fatalf("Radiance HDR failure: expected %v at %d, %d but got %v", expected, x, y, pixel)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package utility
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"io"
+)
+
+// TIFF field types
+const (
+	tiffShort    = 3
+	tiffLong     = 4
+	tiffRational = 5
+)
+
+// Encodes images as uncompressed little endian RGBA TIFF images with unassociated alpha. All pixels are stored in
+// a single strip, images with 16 bits per channel are stored with 16 bits per sample.
+//
+// Reference: https://www.itu.int/itudoc/itu-t/com16/tiff-fx/docs/tiff6.pdf
+type TiffEncoder struct{}
+
+// Create a new TIFF encoder
+func NewTiffEncoder() *TiffEncoder {
+	return &TiffEncoder{}
+}
+
+// A single entry of an image file directory, values that do not fit in four bytes are stored elsewhere
+type tiffEntry struct {
+	tag, fieldType uint16
+	count, value   uint32
+}
+
+// Encode the image as TIFF
+func (t *TiffEncoder) Encode(writer io.Writer, image image.Image) error {
+	bounds := image.Bounds()
+	pixels := encodeSamples(image, true, binary.LittleEndian)
+
+	bitsPerSample := uint16(8)
+	if is16Bit(image) {
+		bitsPerSample = 16
+	}
+
+	// The header is followed by the image file directory, the values that do not fit in it, and the pixels
+	const entryCount = 14
+	const directoryOffset = 8
+	const bitsPerSampleOffset = directoryOffset + 2 + entryCount*12 + 4
+	const resolutionOffset = bitsPerSampleOffset + 8
+	const pixelOffset = resolutionOffset + 8
+
+	entries := []tiffEntry{
+		{256, tiffLong, 1, uint32(bounds.Dx())},
+		{257, tiffLong, 1, uint32(bounds.Dy())},
+		{258, tiffShort, 4, bitsPerSampleOffset},
+		{259, tiffShort, 1, 1},
+		{262, tiffShort, 1, 2},
+		{273, tiffLong, 1, pixelOffset},
+		{277, tiffShort, 1, 4},
+		{278, tiffLong, 1, uint32(bounds.Dy())},
+		{279, tiffLong, 1, uint32(len(pixels))},
+		{282, tiffRational, 1, resolutionOffset},
+		{283, tiffRational, 1, resolutionOffset},
+		{284, tiffShort, 1, 1},
+		{296, tiffShort, 1, 2},
+		{338, tiffShort, 1, 2},
+	}
+
+	output := bytes.Buffer{}
+	output.WriteString("II")
+	binary.Write(&output, binary.LittleEndian, []uint16{42})
+	binary.Write(&output, binary.LittleEndian, []uint32{directoryOffset})
+	binary.Write(&output, binary.LittleEndian, uint16(entryCount))
+
+	for _, entry := range entries {
+		binary.Write(&output, binary.LittleEndian, []uint16{entry.tag, entry.fieldType})
+		binary.Write(&output, binary.LittleEndian, entry.count)
+
+		// Short values are stored in the first two bytes of the value field
+		if entry.fieldType == tiffShort && entry.count == 1 {
+			binary.Write(&output, binary.LittleEndian, []uint16{uint16(entry.value), 0})
+		} else {
+			binary.Write(&output, binary.LittleEndian, entry.value)
+		}
+	}
+
+	// No further image file directories, followed by the bits per sample and a resolution of 72 pixels per inch
+	binary.Write(&output, binary.LittleEndian, uint32(0))
+	binary.Write(&output, binary.LittleEndian, []uint16{bitsPerSample, bitsPerSample, bitsPerSample, bitsPerSample})
+	binary.Write(&output, binary.LittleEndian, []uint32{72, 1})
+
+	if _, error := writer.Write(output.Bytes()); error != nil {
+		return error
+	}
+
+	_, error := writer.Write(pixels)
+	return error
+}
@@ -0,0 +1,189 @@
+package utility
+
+import (
+	"math"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+// Maps linear high dynamic range colors onto linear colors in [0.0, 1.0] that can be displayed
+type ToneMapper interface {
+	Map(color Vec3) Vec3
+}
+
+// Clips every channel to [0.0, 1.0]
+type ClampToneMapper struct{}
+
+// Reinhard operator, compresses highlights while leaving dark colors mostly untouched
+//
+// Reference: https://www-old.cs.utah.edu/docs/techreports/2002/pdf/UUCS-02-001.pdf
+type ReinhardToneMapper struct{}
+
+// Reinhard operator that maps the white point onto pure white instead of approaching it asymptotically
+type ExtendedReinhardToneMapper struct {
+	WhitePoint float64
+}
+
+// Fit of the ACES filmic curve
+//
+// Reference: https://knarkowicz.wordpress.com/2016/01/06/aces-filmic-tone-mapping-curve/
+type AcesToneMapper struct{}
+
+// Filmic curve used in Uncharted 2
+//
+// Reference: http://filmicworlds.com/blog/filmic-tonemapping-operators/
+type Uncharted2ToneMapper struct {
+	WhitePoint float64
+}
+
+// Approximation of AgX, which desaturates bright colors towards white instead of skewing their hue
+//
+// Reference: https://iolite-engine.com/blog_posts/minimal_agx_implementation
+type AgxToneMapper struct{}
+
+// Converts the linear colors of a render into display colors by applying the exposure and tone mapping. Linear
+// transforms store the result without the sRGB encoding, which keeps data such as depth or normals intact.
+type OutputTransform struct {
+	Exposure   float64
+	ToneMapper ToneMapper
+	Linear     bool
+}
+
+// Create a new tone mapper that clips colors
+func NewClampToneMapper() *ClampToneMapper {
+	return &ClampToneMapper{}
+}
+
+// Create a new Reinhard tone mapper
+func NewReinhardToneMapper() *ReinhardToneMapper {
+	return &ReinhardToneMapper{}
+}
+
+// Create a new extended Reinhard tone mapper, the white point is the smallest value that is mapped onto white
+func NewExtendedReinhardToneMapper(whitePoint float64) *ExtendedReinhardToneMapper {
+	return &ExtendedReinhardToneMapper{math.Max(whitePoint, 1e-4)}
+}
+
+// Create a new ACES filmic tone mapper
+func NewAcesToneMapper() *AcesToneMapper {
+	return &AcesToneMapper{}
+}
+
+// Create a new Uncharted 2 tone mapper, the white point is the smallest value that is mapped onto white
+func NewUncharted2ToneMapper(whitePoint float64) *Uncharted2ToneMapper {
+	return &Uncharted2ToneMapper{math.Max(whitePoint, 1e-4)}
+}
+
+// Create a new AgX tone mapper
+func NewAgxToneMapper() *AgxToneMapper {
+	return &AgxToneMapper{}
+}
+
+// Create a new output transform, the exposure is expressed in stops where every stop doubles the brightness
+func NewOutputTransform(exposure float64, toneMapper ToneMapper) OutputTransform {
+	return OutputTransform{exposure, toneMapper, false}
+}
+
+// Create a new output transform for data rather than colors, values are clipped to [0.0, 1.0] and stored as is
+func NewLinearOutputTransform() OutputTransform {
+	return OutputTransform{0.0, NewClampToneMapper(), true}
+}
+
+// Apply a function to every channel of a color
+func mapChannels(color Vec3, function func(value float64) float64) Vec3 {
+	return Vec3{X: function(color.X), Y: function(color.Y), Z: function(color.Z)}
+}
+
+// Clip the color to [0.0, 1.0]
+func (t *ClampToneMapper) Map(color Vec3) Vec3 {
+	return mapChannels(color, func(value float64) float64 {
+		return ClampBetween(value, 0.0, 1.0)
+	})
+}
+
+// Map the color using the Reinhard operator
+func (t *ReinhardToneMapper) Map(color Vec3) Vec3 {
+	return mapChannels(color, func(value float64) float64 {
+		value = math.Max(value, 0.0)
+		return value / (1.0 + value)
+	})
+}
+
+// Map the color using the extended Reinhard operator
+func (t *ExtendedReinhardToneMapper) Map(color Vec3) Vec3 {
+	return mapChannels(color, func(value float64) float64 {
+		value = math.Max(value, 0.0)
+		return math.Min(value*(1.0+value/(t.WhitePoint*t.WhitePoint))/(1.0+value), 1.0)
+	})
+}
+
+// Map the color using the ACES filmic curve
+func (t *AcesToneMapper) Map(color Vec3) Vec3 {
+	return mapChannels(color, func(value float64) float64 {
+		value = math.Max(value, 0.0)
+		return ClampBetween(value*(2.51*value+0.03)/(value*(2.43*value+0.59)+0.14), 0.0, 1.0)
+	})
+}
+
+// Filmic curve of the Uncharted 2 tone mapper
+func uncharted2Curve(value float64) float64 {
+	const a, b, c, d, e, f = 0.15, 0.50, 0.10, 0.20, 0.02, 0.30
+	return (value*(a*value+c*b)+d*e)/(value*(a*value+b)+d*f) - e/f
+}
+
+// Map the color using the Uncharted 2 filmic curve
+func (t *Uncharted2ToneMapper) Map(color Vec3) Vec3 {
+	whiteScale := 1.0 / uncharted2Curve(t.WhitePoint)
+
+	return mapChannels(color, func(value float64) float64 {
+		return ClampBetween(uncharted2Curve(math.Max(value, 0.0))*whiteScale, 0.0, 1.0)
+	})
+}
+
+// Matrices that move colors into and out of the working space of AgX
+var agxInset = Mat3{
+	{0.842479062253094, 0.0784335999999992, 0.0792237451477643},
+	{0.0423282422610123, 0.878468636469772, 0.0791661274605434},
+	{0.0423756549057051, 0.0784336, 0.879142973793104},
+}
+var agxOutset = Mat3{
+	{1.19687900512017, -0.0980208811401368, -0.0990297440797205},
+	{-0.0528968517574562, 1.15190312990417, -0.0989611768448433},
+	{-0.0529716355144438, -0.0980434501171241, 1.15107367264116},
+}
+
+// Map the color using AgX. The color is encoded logarithmically, shaped by a sigmoid curve, and decoded back
+// into linear values.
+func (t *AgxToneMapper) Map(color Vec3) Vec3 {
+	const minExposure, maxExposure = -12.47393, 4.026069
+
+	color = MultiplyMat3Vec3(agxInset, color)
+	color = mapChannels(color, func(value float64) float64 {
+		value = ClampBetween(math.Log2(math.Max(value, 1e-10)), minExposure, maxExposure)
+		value = (value - minExposure) / (maxExposure - minExposure)
+
+		// Polynomial approximation of the AgX contrast curve
+		x2 := value * value
+		x4 := x2 * x2
+		return 15.5*x4*x2 - 40.14*x4*value + 31.96*x4 - 6.868*x2*value + 0.4298*x2 + 0.1191*value - 0.00232
+	})
+	color = MultiplyMat3Vec3(agxOutset, color)
+
+	return mapChannels(color, func(value float64) float64 {
+		return math.Pow(ClampBetween(value, 0.0, 1.0), 2.2)
+	})
+}
+
+// Convert a linear color of a render into a linear display color
+func (t *OutputTransform) Apply(color Vec3) Vec3 {
+	return t.ToneMapper.Map(MultiplyScalar(color, math.Exp2(t.Exposure)))
+}
+
+// Encode a channel of a linear display color in [0.0, 1.0] for storage, using sRGB unless the transform is linear
+func (t *OutputTransform) Encode(value float64) float64 {
+	if t.Linear {
+		return value
+	}
+
+	return LinearToSrgb(value)
+}
@@ -0,0 +1,77 @@
+package utility
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/tntmeijs/gengo/mathematics"
+)
+
+func gray(value float64) Vec3 {
+	return Vec3{X: value, Y: value, Z: value}
+}
+
+func TestToneMappersStayInRange(t *testing.T) {
+	toneMappers := []ToneMapper{
+		NewClampToneMapper(),
+		NewReinhardToneMapper(),
+		NewExtendedReinhardToneMapper(4.0),
+		NewAcesToneMapper(),
+		NewUncharted2ToneMapper(11.2),
+		NewAgxToneMapper(),
+	}
+
+	for _, toneMapper := range toneMappers {
+		previous := -1.0
+
+		for _, value := range []float64{0.0, 0.01, 0.1, 0.5, 1.0, 2.0, 10.0, 1000.0} {
+			mapped := toneMapper.Map(gray(value)).X
+
+			if mapped < 0.0 || mapped > 1.0 {
+				t.Fatalf("Tone mapping failure: %T mapped %f outside of [0.0, 1.0] onto %f", toneMapper, value, mapped)
+			}
+
+			if mapped < previous {
+				t.Fatalf("Tone mapping failure: %T is not monotonic at %f", toneMapper, value)
+			}
+
+			previous = mapped
+		}
+	}
+}
+
+func TestToneMapperValues(t *testing.T) {
+	reinhard := NewReinhardToneMapper()
+	if mapped := reinhard.Map(gray(1.0)).X; mapped != 0.5 {
+		t.Fatalf("Reinhard failure: expected 0.5 but got %f", mapped)
+	}
+
+	extended := NewExtendedReinhardToneMapper(4.0)
+	if mapped := extended.Map(gray(4.0)).X; math.Abs(mapped-1.0) > epsilon {
+		t.Fatalf("Extended Reinhard failure: expected the white point to map onto 1.0 but got %f", mapped)
+	}
+
+	uncharted := NewUncharted2ToneMapper(11.2)
+	if mapped := uncharted.Map(gray(11.2)).X; math.Abs(mapped-1.0) > epsilon {
+		t.Fatalf("Uncharted 2 failure: expected the white point to map onto 1.0 but got %f", mapped)
+	}
+
+	aces := NewAcesToneMapper()
+	if mapped := aces.Map(gray(0.0)).X; mapped != 0.0 {
+		t.Fatalf("ACES failure: expected black to stay black but got %f", mapped)
+	}
+
+	// AgX keeps neutral colors neutral
+	agx := NewAgxToneMapper()
+	if mapped := agx.Map(gray(0.18)); math.Abs(mapped.X-mapped.Y) > epsilon || math.Abs(mapped.Y-mapped.Z) > epsilon {
+		t.Fatalf("AgX failure: expected a neutral gray but got %v", mapped)
+	}
+}
+
+func TestOutputTransformExposure(t *testing.T) {
+	transform := NewOutputTransform(1.0, NewClampToneMapper())
+
+	if result := transform.Apply(gray(0.25)); result != gray(0.5) {
+		t.Fatalf("Output transform failure: expected one stop of exposure to double the color but got %v", result)
+	}
+}